@@ -3,27 +3,24 @@ package main
 import (
 	"bytes"
 	"context"
-	"crypto/sha1"
-	"encoding/json"
 	"fmt"
-	"io"
 	"io/ioutil"
 	"log"
+	"log/slog"
 	"net/http"
 	"os"
-	"regexp"
-	"strings"
-	"sync"
 	"time"
 
 	"cloud.google.com/go/errorreporting"
 	"cloud.google.com/go/storage"
 	"github.com/lutzky/repy"
-	"github.com/lutzky/repy/recode"
-	"github.com/lutzky/repy/writerlogger"
+	"github.com/lutzky/repy/fetch"
+	"github.com/lutzky/repy/metrics"
+	"github.com/lutzky/repy/notify"
+	"github.com/lutzky/repy/publish"
 	"github.com/pkg/errors"
-	"golang.org/x/sync/errgroup"
-	"golang.org/x/text/encoding/charmap"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/api/iterator"
 )
 
@@ -31,14 +28,35 @@ var bucketName string
 
 const defaultBucketName = "staging.repy-176217.appspot.com"
 
+// subscriptionsBucketName holds subscriptionsFileName, which lists
+// notify.Subscriptions. It must be a private bucket, as subscriptions include
+// secrets. If unset, no notifications are sent.
+var subscriptionsBucketName string
+
+const subscriptionsFileName = "subscriptions.json"
+
+// repFileFetcher is shared between requests, so that unchanged REPFILE.zip
+// files aren't downloaded again while the instance is alive.
+var repFileFetcher = &fetch.Fetcher{}
+
+// Metrics are exported on /metrics.
+var (
+	parserMetrics = metrics.NewParser(prometheus.DefaultRegisterer)
+	updateMetrics = metrics.NewUpdates(prometheus.DefaultRegisterer)
+)
+
 func downloadREPYZip(ctx context.Context) ([]byte, error) {
-	resp, err := http.Get(repy.RepFileURL)
+	start := time.Now()
+	result, err := repFileFetcher.Fetch(ctx, repy.RepFileURL)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to download %q", repy.RepFileURL)
 	}
-	defer resp.Body.Close()
+	updateMetrics.ObserveDownload(time.Since(start), len(result.Body))
+	if result.NotModified {
+		log.Printf("%s not modified since last download", repy.RepFileURL)
+	}
 
-	return repy.ExtractFromZip(resp.Body)
+	return repy.ExtractFromZip(bytes.NewReader(result.Body))
 }
 
 var errorClient *errorreporting.Client
@@ -67,6 +85,7 @@ func initErrorReporting(ctx context.Context) {
 
 func main() {
 	http.HandleFunc("/update", handler)
+	http.Handle("/metrics", promhttp.Handler())
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8080"
@@ -82,126 +101,101 @@ func main() {
 	}
 	log.Printf("Using cloud storage bucket %q", bucketName)
 
+	subscriptionsBucketName = os.Getenv("SUBSCRIPTIONS_BUCKET")
+	if subscriptionsBucketName == "" {
+		log.Printf("SUBSCRIPTIONS_BUCKET is unset, so course change notifications are disabled")
+	} else {
+		log.Printf("Using subscriptions bucket %q", subscriptionsBucketName)
+	}
+
 	log.Printf("Listening on port %s", port)
 	log.Fatal(http.ListenAndServe(fmt.Sprintf(":%s", port), nil))
 }
 
-type repyStorer struct {
-	ctx           context.Context
-	bucket        *storage.BucketHandle
-	data          []byte
-	sha1sum       [20]byte
-	cacheDisabled bool
+// bucketStore is a publish.Store which writes objects into a cloud storage
+// bucket.
+type bucketStore struct {
+	bucket *storage.BucketHandle
+
+	// public objects are made readable by all users upon writing.
+	public bool
 }
 
-func newRepyStorer(ctx context.Context, data []byte) (*repyStorer, error) {
+var _ publish.Store = bucketStore{}
+
+func newBucketStore(ctx context.Context, name string, public bool) (bucketStore, error) {
 	client, err := storage.NewClient(ctx)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get storage client")
-	}
-
-	result := &repyStorer{
-		ctx:     ctx,
-		bucket:  client.Bucket(bucketName),
-		data:    data,
-		sha1sum: sha1.Sum(data),
+		return bucketStore{}, errors.Wrap(err, "failed to get storage client")
 	}
-
-	log.Printf("REPY SHA1SUM: %x", result.sha1sum)
-
-	return result, nil
+	return bucketStore{bucket: client.Bucket(name), public: public}, nil
 }
 
-func (rs *repyStorer) writeAllREPYFiles() error {
-	repyBytesISO8859_8, err := recode.Recode(charmap.CodePage862, charmap.ISO8859_8, rs.data)
-	if err != nil {
-		return errors.Wrap(err, "failed to convert REPY to ISO8859-8")
-	}
-
-	baseFileName := fmt.Sprintf("%x.repy", rs.sha1sum)
-
-	isMissing := true
-
-	if exists, err := rs.fileExists(baseFileName); err != nil {
-		return errors.Wrapf(err, "Coudln't check if %q already exists", baseFileName)
-	} else if exists {
-		log.Printf("%q already exists", baseFileName)
-		isMissing = false
-	}
-
-	destinations := []struct {
-		filename      string
-		contentType   string
-		data          []byte
-		onlyIfMissing bool
-	}{
-		{baseFileName, "text/plain; charset=cp862", rs.data, true},
-		{fmt.Sprintf("%x.txt", rs.sha1sum), "text/plain; charset=iso8859-8", repyBytesISO8859_8, true},
-		{"latest.txt", "text/plain; charset=iso8859-8", repyBytesISO8859_8, false},
-		{"latest.repy", "text/plain; charset=cp862", rs.data, false},
-	}
-
-	var g errgroup.Group
-	for _, dest := range destinations {
-		dest := dest
-		g.Go(func() error {
-			if dest.onlyIfMissing && !isMissing {
-				return nil
-			}
-			log.Printf("writing %q with content-type %q", dest.filename, dest.contentType)
-			if err := rs.copyToFile(dest.filename, bytes.NewReader(dest.data)); err != nil {
-				return errors.Wrapf(err, "failed to write %q", dest.filename)
-			}
-			if err := rs.setContentType(dest.filename, dest.contentType); err != nil {
-				return err
-			}
-			return nil
-		})
+// Write implements publish.Store.Write.
+func (bs bucketStore) Write(ctx context.Context, filename, contentType string, data []byte) error {
+	obj := bs.bucket.Object(filename)
+	w := obj.NewWriter(ctx)
+	w.ContentType = contentType
+	if _, err := w.Write(data); err != nil {
+		w.Close()
+		return errors.Wrapf(err, "failed to write %q", filename)
 	}
-
-	if err := g.Wait(); err != nil {
-		return err
+	if err := w.Close(); err != nil {
+		return errors.Wrapf(err, "failed to write %q", filename)
 	}
-
-	if isMissing {
-		f := fmt.Sprintf("%x.timestamp", rs.sha1sum)
-		log.Printf("Writing timestamp file %q", f)
-		if err := rs.writeTimeStamp(f, time.Now()); err != nil {
-			return err
+	if bs.public {
+		if err := obj.ACL().Set(ctx, storage.AllUsers, storage.RoleReader); err != nil {
+			log.Printf("Failed to make %q public: %v", filename, err)
 		}
 	}
-
 	return nil
 }
 
-func (rs *repyStorer) parseJSONAndWrite() error {
-	parseLogWriter, parseLogCloser := rs.makePublicObject("latest.parse.log")
-	defer parseLogCloser()
+// Read implements publish.Store.Read.
+func (bs bucketStore) Read(ctx context.Context, filename string) ([]byte, error) {
+	r, err := bs.bucket.Object(filename).NewReader(ctx)
+	if err == storage.ErrObjectNotExist {
+		return nil, errors.Wrapf(publish.ErrNotExist, "%q", filename)
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "couldn't open %q", filename)
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
 
-	catalog, err := repy.ReadFile(bytes.NewReader(rs.data), writerlogger.Logger{W: parseLogWriter})
-	if err != nil {
-		fmt.Fprintf(parseLogWriter, "Read returned error: %v\n", err)
-		return errors.Wrap(err, "failed to read catalog")
+// Exists implements publish.Store.Exists.
+func (bs bucketStore) Exists(ctx context.Context, filename string) (bool, error) {
+	_, err := bs.bucket.Object(filename).Attrs(ctx)
+	switch err {
+	case nil:
+		return true, nil
+	case storage.ErrObjectNotExist:
+		return false, nil
+	default:
+		return false, errors.Wrapf(err, "couldn't check if %q exists", filename)
 	}
+}
 
-	for _, filename := range []string{fmt.Sprintf("%x.json", rs.sha1sum), "latest.json"} {
-		jsonWriter, jsonCloser := rs.makePublicObject(filename)
-		defer jsonCloser()
-		enc := json.NewEncoder(jsonWriter)
-		enc.SetIndent("", "  ")
+// List implements publish.Store.List.
+func (bs bucketStore) List(ctx context.Context) ([]string, error) {
+	result := []string{}
 
-		if err := enc.Encode(catalog); err != nil {
-			return errors.Wrapf(err, "failed to write %q", filename)
+	it := bs.bucket.Objects(ctx, nil)
+	for {
+		objAttrs, err := it.Next()
+		if err == iterator.Done {
+			break
 		}
+		if err != nil {
+			return nil, errors.Wrap(err, "Problem iterating")
+		}
+		result = append(result, objAttrs.Name)
 	}
 
-	return nil
+	return result, nil
 }
 
-const (
-	catalogFileName = "catalog.json"
-	cronHeader      = "X-Appengine-Cron"
-)
+const cronHeader = "X-Appengine-Cron"
 
 func handler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -214,324 +208,94 @@ func handler(w http.ResponseWriter, r *http.Request) {
 
 	repyBytes, err := downloadREPYZip(ctx)
 	if err != nil {
+		updateMetrics.ObserveResult(metrics.ResultError)
 		httpErrorWrap(ctx, w, r, err, "Failed to download REPY zip")
 		return
 	}
 
-	rs, err := newRepyStorer(ctx, repyBytes)
+	store, err := newBucketStore(ctx, bucketName, true)
 	if err != nil {
+		updateMetrics.ObserveResult(metrics.ResultError)
 		httpErrorWrap(ctx, w, r, err, "Failed to initialize REPY App")
 		return
 	}
 
-	if r.FormValue("nocache") == "1" {
-		rs.cacheDisabled = true
-	}
-
-	if err := rs.writeAllREPYFiles(); err != nil {
-		httpErrorWrap(ctx, w, r, err, "Failed to write REPY files")
-		return
-	}
-
-	if err := rs.parseJSONAndWrite(); err != nil {
-		httpErrorWrap(ctx, w, r, err, "Failed to complete JSON Parsing")
-		return
-	}
-
-	log.Printf("Successfully parsed REPY")
-
-	log.Printf("Writing %s", catalogFileName)
-
-	if err := rs.writeCatalog(); err != nil {
-		httpErrorWrap(ctx, w, r, err, "Failed to write catalog")
-		return
-	}
-
-	log.Printf("Success")
-	fmt.Fprintf(w, "Success")
-}
-
-func (rs *repyStorer) copyToFile(filename string, r io.Reader) error {
-	w, closer := rs.makePublicObject(filename)
-	defer closer()
-
-	_, err := io.Copy(w, r)
-
-	return err
-}
-
-func httpErrorWrap(ctx context.Context, w http.ResponseWriter, r *http.Request, err error, msg string) {
-	log.Printf(errors.Wrap(err, msg).Error())
-	if errorClient != nil {
-		errorClient.Report(errorreporting.Entry{
-			Error: err,
-			Req:   r,
-		})
+	var stats repy.Stats
+	opts := publish.Options{
+		NoCache:    r.FormValue("nocache") == "1",
+		ParseStats: &stats,
+		Logger:     repy.SlogLogger{Handler: slog.Default().Handler()},
 	}
-	http.Error(w, msg, http.StatusInternalServerError)
-}
 
-// makePublicObject opens a file in the specified client and bucket with the
-// given name, and returns a writer to it as well as a closer function. Caller
-// must call the closer function when done writing to the file (e.g. using
-// defer). The object will be made public upon closing.
-func (rs *repyStorer) makePublicObject(filename string) (io.Writer, func()) {
-	obj := rs.bucket.Object(filename)
-	w := obj.NewWriter(rs.ctx)
-	closer := func() {
-		w.Close()
-		if err := obj.ACL().Set(rs.ctx, storage.AllUsers, storage.RoleReader); err != nil {
-			log.Printf("Failed to make %q public: %v", filename, err)
+	result, err := publish.Publish(ctx, updateMetrics.Store(store), repyBytes, opts)
+	if stats.Lines > 0 {
+		// Storage failures are only counted by updateMetrics.
+		var parseErr *publish.ParseError
+		if errors.As(err, &parseErr) {
+			parserMetrics.Observe(stats, parseErr)
+		} else {
+			parserMetrics.Observe(stats, nil)
 		}
 	}
-	return w, closer
-}
-
-func (rs *repyStorer) setContentType(filename string, contentType string) error {
-	obj := rs.bucket.Object(filename)
-	if _, err := obj.Update(rs.ctx, storage.ObjectAttrsToUpdate{ContentType: contentType}); err != nil {
-		return errors.Wrapf(err, "failed to set content-type for %q to %q", filename, contentType)
-	}
-
-	return nil
-}
-
-func (rs *repyStorer) fileExists(filename string) (bool, error) {
-	obj := rs.bucket.Object(filename)
-	_, err := obj.Attrs(rs.ctx)
-	switch err {
-	case nil:
-		return true, nil
-	case storage.ErrObjectNotExist:
-		return false, nil
-	default:
-		return false, errors.Wrapf(err, "couldn't check if %q exists", filename)
-	}
-}
-
-func (rs *repyStorer) writeTimeStamp(filename string, t time.Time) error {
-	w, closer := rs.makePublicObject(filename)
-	defer closer()
-	if _, err := fmt.Fprintf(w, "%s\n", t.UTC().Format(time.UnixDate)); err != nil {
-		return errors.Wrapf(err, "couldn't write timestamp %q", filename)
-	}
-	return nil
-}
-
-type metadataEntry struct {
-	sha1sum  string
-	t        time.Time
-	semester string
-}
-
-// Catalog is the format for catalogFileName
-type Catalog struct {
-	Entries []CatalogEntry
-}
-
-// CatalogEntry is an entry for Catalog
-type CatalogEntry struct {
-	Sha1Sum   string
-	Original  string
-	Iso8859_8 string
-	Parsed    string
-	TimeStamp time.Time
-	Semester  string
-}
-
-func (rs *repyStorer) writeCatalog() error {
-	metadata, err := rs.getAllMetadata()
 	if err != nil {
-		return errors.Wrap(err, "failed to get timestamps and sums")
-	}
-
-	catalog := Catalog{Entries: []CatalogEntry{}}
-
-	for _, m := range metadata {
-		catalog.Entries = append(catalog.Entries, CatalogEntry{
-			Sha1Sum:   m.sha1sum,
-			TimeStamp: m.t,
-			Semester:  m.semester,
-			Iso8859_8: m.sha1sum + ".txt",
-			Original:  m.sha1sum + ".repy",
-			Parsed:    m.sha1sum + ".json",
-		})
+		updateMetrics.ObserveResult(metrics.ResultError)
+		httpErrorWrap(ctx, w, r, err, "Failed to publish REPY")
+		return
 	}
 
-	jsonBytes, err := json.Marshal(catalog)
-	if err != nil {
-		return errors.Wrap(err, "failed to format JSON")
+	if result.New {
+		updateMetrics.ObserveResult(metrics.ResultNew)
+	} else {
+		updateMetrics.ObserveResult(metrics.ResultUnchanged)
 	}
 
-	if err := rs.copyToFile(catalogFileName, bytes.NewReader(jsonBytes)); err != nil {
-		return errors.Wrapf(err, "failed to write JSON to %q", catalogFileName)
+	if result.New && result.Previous != nil {
+		if err := notifySubscribers(ctx, result); err != nil {
+			// The new version was published successfully, so this is only
+			// reported.
+			reportError(r, errors.Wrap(err, "failed to notify subscribers"))
+		}
 	}
 
-	return nil
+	log.Printf("Success")
+	fmt.Fprintf(w, "Success")
 }
 
-func (rs *repyStorer) loadMetaCatalog() map[string]metadataEntry {
-	if rs.cacheDisabled {
-		log.Printf("Bypassing cache")
+func notifySubscribers(ctx context.Context, result *publish.Result) error {
+	if subscriptionsBucketName == "" {
 		return nil
 	}
 
-	log.Printf("Reading cached catalog from %q", catalogFileName)
-
-	obj := rs.bucket.Object(catalogFileName)
-	r, err := obj.NewReader(rs.ctx)
-	if err != nil {
-		log.Printf("Failed to read %q: %v", catalogFileName, err)
+	changes := repy.DiffCatalogs(result.Previous, result.Catalog)
+	log.Printf("%d courses changed from %s to %s", len(changes), result.PreviousSHA1Sum, result.SHA1Sum)
+	if len(changes) == 0 {
 		return nil
 	}
-	dec := json.NewDecoder(r)
-	catalog := Catalog{}
-
-	if err := dec.Decode(&catalog); err != nil {
-		log.Printf("Failed to unmarshal %q: %v", catalogFileName, err)
-	}
-
-	result := map[string]metadataEntry{}
-	for _, entry := range catalog.Entries {
-		result[entry.Sha1Sum] = metadataEntry{
-			sha1sum:  entry.Sha1Sum,
-			semester: entry.Semester,
-			t:        entry.TimeStamp,
-		}
-	}
-	return result
-}
-
-func (rs *repyStorer) getAllMetadata() ([]metadataEntry, error) {
-	sums, err := rs.getExistingSHA1Sums()
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to list existing REPY data")
-	}
-
-	cache := rs.loadMetaCatalog()
-
-	var wg errgroup.Group
-	ch := make(chan metadataEntry)
-
-	for _, sum := range sums {
-		sum := sum
-		wg.Go(func() error {
-			if entry, ok := cache[sum]; ok {
-				ch <- entry
-				return nil
-			}
-
-			ts, err := rs.getTimeStampForSHA1Sum(sum)
-			if err != nil {
-				log.Printf("Couldn't get timestamp for %q: %v", sum, err)
-				return err
-			}
-
-			semester := rs.getSemesterForSHA1Sum(sum)
-
-			ch <- metadataEntry{
-				sha1sum:  sum,
-				t:        ts,
-				semester: semester,
-			}
-			return nil
-		})
-	}
-
-	results := []metadataEntry{}
-
-	var wg2 sync.WaitGroup
-
-	wg2.Add(1)
-	go func() {
-		for ts := range ch {
-			results = append(results, ts)
-		}
-		wg2.Done()
-	}()
-
-	err = wg.Wait()
-	close(ch)
-
-	wg2.Wait()
-
-	return results, err
-}
-
-var repyFileRegexp = regexp.MustCompile(`[0-9a-f]{20}.repy`)
-
-func (rs *repyStorer) getExistingSHA1Sums() ([]string, error) {
-	result := []string{}
-
-	it := rs.bucket.Objects(rs.ctx, nil)
-	for {
-		objAttrs, err := it.Next()
-		if err == iterator.Done {
-			break
-		}
-		if err != nil {
-			return nil, errors.Wrap(err, "Problem iterating")
-		}
-
-		if repyFileRegexp.MatchString(objAttrs.Name) {
-			sha1sum := strings.TrimSuffix(objAttrs.Name, ".repy")
-			result = append(result, sha1sum)
-		}
-	}
-
-	return result, nil
-}
-
-func (rs *repyStorer) getTimeStampForSHA1Sum(sha1sum string) (time.Time, error) {
-	filename := sha1sum + ".timestamp"
-	obj := rs.bucket.Object(filename)
-	r, err := obj.NewReader(rs.ctx)
-	if err != nil {
-		return time.Time{}, errors.Wrapf(err, "couldn't open %q", filename)
-	}
 
-	data, err := ioutil.ReadAll(r)
+	objects, err := newBucketStore(ctx, subscriptionsBucketName, false)
 	if err != nil {
-		return time.Time{}, errors.Wrapf(err, "couldn't read from %q", filename)
+		return err
 	}
-
-	timestamp := strings.TrimSpace(string(data))
-
-	t, err := time.Parse(time.UnixDate, timestamp)
+	subs, err := (&notify.ObjectStore{Objects: objects, Name: subscriptionsFileName}).List(ctx)
 	if err != nil {
-		return time.Time{}, errors.Wrapf(err, "couldn't parse time %q", timestamp)
+		return err
 	}
 
-	return t, nil
+	var n notify.Notifier
+	return n.Notify(ctx, subs, changes, result.PreviousSHA1Sum, result.SHA1Sum)
 }
 
-func (rs *repyStorer) getSemesterForSHA1Sum(sha1sum string) string {
-	filename := sha1sum + ".json"
-	obj := rs.bucket.Object(filename)
-	r, err := obj.NewReader(rs.ctx)
-	if err != nil {
-		log.Printf("Failed to read %q: %v", filename, err)
-		return ""
-	}
-
-	dec := json.NewDecoder(r)
-	catalog := repy.Catalog{}
-	if err := dec.Decode(&catalog); err != nil {
-		log.Printf("Failed to unmarshal %q: %v", filename, err)
-		return ""
-	}
-
-	if len(catalog) == 0 {
-		return "No faculties"
-	}
-
-	semester := catalog[0].Semester
-
-	for _, faculty := range catalog {
-		if faculty.Semester != semester {
-			return "INCONSISTENT"
-		}
+func reportError(r *http.Request, err error) {
+	log.Print(err)
+	if errorClient != nil {
+		errorClient.Report(errorreporting.Entry{
+			Error: err,
+			Req:   r,
+		})
 	}
+}
 
-	return semester
+func httpErrorWrap(ctx context.Context, w http.ResponseWriter, r *http.Request, err error, msg string) {
+	reportError(r, errors.Wrap(err, msg))
+	http.Error(w, msg, http.StatusInternalServerError)
 }
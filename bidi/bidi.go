@@ -99,3 +99,76 @@ func (rs runeStack) toRevString() string {
 	}
 	return string(runes)
 }
+
+// ltrSeparators may appear within a left-to-right run, if surrounded by
+// left-to-right characters.
+const ltrSeparators = ".,/:-"
+
+func isLTR(r rune) bool {
+	return unicode.IsDigit(r) || unicode.Is(unicode.Latin, r)
+}
+
+// Reorder converts an entire right-to-left line between logical and visual
+// order. The line is reversed, except for left-to-right runs (Latin letters and
+// numbers, along with separators between them, and spaces between Latin words)
+// which keep their internal order. Unlike Reverse, which is tuned for the
+// contents of REPY fields, Reorder is its own inverse, and preserves the layout
+// of the line.
+func Reorder(s string) string {
+	runes := []rune(s)
+	n := len(runes)
+
+	ltr := make([]bool, n)
+	for i, r := range runes {
+		if isLTR(r) {
+			ltr[i] = true
+		} else if strings.ContainsRune(ltrSeparators, r) && i > 0 && i < n-1 {
+			ltr[i] = isLTR(runes[i-1]) && isLTR(runes[i+1])
+		}
+	}
+	// Spaces between Latin words take their direction, so that words within
+	// a Latin phrase keep their order.
+	for i := 0; i < n; i++ {
+		if runes[i] != ' ' || i == 0 || !unicode.Is(unicode.Latin, runes[i-1]) {
+			continue
+		}
+		end := i
+		for end < n && runes[end] == ' ' {
+			end++
+		}
+		if end < n && unicode.Is(unicode.Latin, runes[end]) {
+			for j := i; j < end; j++ {
+				ltr[j] = true
+			}
+		}
+		i = end - 1
+	}
+
+	result := make([]rune, 0, n)
+	for i := n - 1; i >= 0; i-- {
+		if !ltr[i] {
+			result = append(result, mirror(runes[i]))
+			continue
+		}
+		start := i
+		for start > 0 && ltr[start-1] {
+			start--
+		}
+		result = append(result, runes[start:i+1]...)
+		i = start
+	}
+
+	return string(result)
+}
+
+// Visual converts a string in logical order for display on terminals which
+// don't support bidirectional text. Strings with Hebrew letters are converted
+// as right-to-left lines (see Reorder); other strings are returned as they are.
+func Visual(s string) string {
+	for _, r := range s {
+		if unicode.Is(unicode.Hebrew, r) {
+			return Reorder(s)
+		}
+	}
+	return s
+}
@@ -1,6 +1,14 @@
 package bidi
 
-import "testing"
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+)
 
 func TestReverse(t *testing.T) {
 	testCases := []struct {
@@ -27,9 +35,85 @@ func TestReverse(t *testing.T) {
 	}
 }
 
+func TestReorder(t *testing.T) {
+	testCases := []struct {
+		s, want string
+	}{
+		{"הסדנה", "הנדסה"},
+		{"| םולש 234114 |", "| 234114 שלום |"},
+		{"7.00- 8.30'ג", "ג'8.30 -7.00"},
+		{"(הלאכ) םיירגוס", "סוגריים (כאלה)"},
+		{"Hello world", "Hello world"},
+		{"הרצאה Intro to CS 101", "101 Intro to CS האצרה"},
+		{"A 1", "1 A"},
+	}
+
+	for _, tc := range testCases {
+		got := Reorder(tc.s)
+		if got != tc.want {
+			t.Errorf("Reorder(%q) = %q; want %q", tc.s, got, tc.want)
+		}
+		if back := Reorder(got); back != tc.s {
+			t.Errorf("Reorder(Reorder(%q)) = %q; want original", tc.s, back)
+		}
+	}
+}
+
+func TestVisual(t *testing.T) {
+	testCases := []struct {
+		s, want string
+	}{
+		{"Hello world", "Hello world"},
+		{"12:30-14:30", "12:30-14:30"},
+		{"שלום 234114", "234114 םולש"},
+		{"אולמן 101", "101 ןמלוא"},
+	}
+
+	for _, tc := range testCases {
+		if got := Visual(tc.s); got != tc.want {
+			t.Errorf("Visual(%q) = %q; want %q", tc.s, got, tc.want)
+		}
+	}
+}
+
 func BenchmarkSimple(b *testing.B) {
 	input := "לקראת סוף המאה ה-19"
 	for i := 0; i < b.N; i++ {
 		Reverse(input)
 	}
 }
+
+func FuzzReverse(f *testing.F) {
+	for _, tc := range []string{"hello", "19-ה האמה", "(הלאכ) םיירגוס"} {
+		f.Add(tc)
+	}
+
+	// The testdata REPY files are a good source of realistic visual-Hebrew
+	// lines.
+	files, err := filepath.Glob("../testdata/*.repy")
+	if err != nil {
+		f.Fatalf("Failed to glob testdata: %v", err)
+	}
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			f.Fatalf("Couldn't read %q: %v", file, err)
+		}
+		decoded, err := charmap.CodePage862.NewDecoder().Bytes(data)
+		if err != nil {
+			f.Fatalf("Couldn't decode %q: %v", file, err)
+		}
+		for i, line := range strings.Split(string(decoded), "\n") {
+			if i%50 == 0 {
+				f.Add(line)
+			}
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		got := Reverse(s)
+		if want := utf8.RuneCountInString(s); utf8.RuneCountInString(got) != want {
+			t.Errorf("Reverse(%q) = %q, with %d runes; want %d runes", s, got, utf8.RuneCountInString(got), want)
+		}
+	})
+}
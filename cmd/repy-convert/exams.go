@@ -0,0 +1,110 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/lutzky/repy"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+var moedNames = []string{"A", "B", "C"}
+
+func moedName(moed int) string {
+	if moed < len(moedNames) {
+		return moedNames[moed]
+	}
+	return fmt.Sprint(moed + 1)
+}
+
+// examsMain implements "repy-convert exams", which reports exam clashes within
+// sets of courses listed in a YAML file, e.g.:
+//
+//   - name: Semester 1
+//     courses: [104031, 234114]
+//   - name: Semester 2
+//     courses: [104032, 234124]
+func examsMain(args []string) error {
+	fs := flag.NewFlagSet("exams", flag.ExitOnError)
+	inputFile := fs.String("input_file", "/dev/stdin", "REPY file to read")
+	setsFile := fs.String("sets_file", "", "YAML file listing sets of courses")
+	outputFile := fs.String("output_file", "/dev/stdout", "File to write the report to")
+	minDays := fs.Int("min_days", 3, "Report exams fewer than this many days apart")
+	asJSON := fs.Bool("json", false, "Write the report as JSON")
+	fs.Parse(args)
+
+	if *setsFile == "" {
+		return errors.New("-sets_file is required")
+	}
+
+	sets, err := readCourseSets(*setsFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read course sets from %q", *setsFile)
+	}
+
+	catalog, err := readREPYFile(*inputFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read REPY input")
+	}
+
+	reports := repy.AnalyzeExams(catalog, sets, *minDays)
+
+	if *asJSON {
+		return writeJSONFile(*outputFile, reports)
+	}
+
+	f, err := os.Create(*outputFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	for _, r := range reports {
+		writeExamReport(f, r)
+	}
+	return nil
+}
+
+func readCourseSets(filename string) ([]repy.CourseSet, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var sets []repy.CourseSet
+	if err := yaml.UnmarshalStrict(data, &sets); err != nil {
+		return nil, err
+	}
+	return sets, nil
+}
+
+func formatDate(d repy.Date) string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+func formatExam(e repy.Exam) string {
+	return fmt.Sprintf("%s %s (%s)", e.CourseID, e.CourseName, formatDate(e.Date))
+}
+
+func writeExamReport(w io.Writer, r repy.ExamReport) {
+	fmt.Fprintf(w, "== %s ==\n", r.Set.Name)
+	for _, id := range r.MissingCourses {
+		fmt.Fprintf(w, "  Course %s not found in catalog\n", id)
+	}
+	for _, s := range r.Spreads {
+		fmt.Fprintf(w, "  Moed %s: %s to %s (%d days)\n",
+			moedName(s.Moed), formatDate(s.First), formatDate(s.Last), s.Days)
+	}
+	for _, c := range r.SameDay {
+		fmt.Fprintf(w, "  SAME DAY (moed %s): %s and %s\n",
+			moedName(c.First.Moed), formatExam(c.First), formatExam(c.Second))
+	}
+	for _, c := range r.TooClose {
+		fmt.Fprintf(w, "  %d DAYS APART (moed %s): %s and %s\n",
+			c.DaysApart, moedName(c.First.Moed), formatExam(c.First), formatExam(c.Second))
+	}
+	fmt.Fprintln(w)
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/lutzky/repy"
+	"github.com/pkg/errors"
+)
+
+// parseDays parses a comma-separated list of English day names, or their
+// 3-letter abbreviations.
+func parseDays(s string) ([]time.Weekday, error) {
+	var result []time.Weekday
+	for _, name := range strings.Split(s, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		found := false
+		for d := time.Sunday; d <= time.Saturday; d++ {
+			full := strings.ToLower(d.String())
+			if name == full || name == full[:3] {
+				result = append(result, d)
+				found = true
+			}
+		}
+		if !found {
+			return nil, errors.Errorf("invalid day %q", name)
+		}
+	}
+	return result, nil
+}
+
+// freetimeMain implements "repy-convert freetime", which lists the weekly
+// slots in which everyone is free, given a selection file per person (e.g. as
+// saved by repy-tui).
+func freetimeMain(args []string) error {
+	fs := flag.NewFlagSet("freetime", flag.ExitOnError)
+	inputFile := fs.String("input_file", "/dev/stdin", "REPY file to read")
+	outputFile := fs.String("output_file", "/dev/stdout", "File to write free slots to")
+	days := fs.String("days", "sun,mon,tue,wed,thu", "Comma-separated days to look for free time in")
+	from := fs.String("from", "08:30", "Earliest time of day for free slots")
+	to := fs.String("to", "20:30", "Latest time of day for free slots")
+	minLength := fs.Int("min_length", 60, "Minimal length of free slots, in minutes")
+	asJSON := fs.Bool("json", false, "Write free slots as JSON")
+	clockTimes := fs.Bool("clock_times", false, "Write times in JSON as HH:MM rather than minutes since midnight")
+	fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		return errors.New("no selection files given")
+	}
+
+	opts := repy.FreeTimeOptions{MinLength: repy.MinutesSinceMidnight(*minLength)}
+	var err error
+	if opts.Days, err = parseDays(*days); err != nil {
+		return err
+	}
+	if opts.From, err = repy.ParseMinutesSinceMidnight(*from); err != nil {
+		return err
+	}
+	if opts.To, err = repy.ParseMinutesSinceMidnight(*to); err != nil {
+		return err
+	}
+
+	var selections []repy.Selection
+	for _, filename := range fs.Args() {
+		f, err := os.Open(filename)
+		if err != nil {
+			return err
+		}
+		s, err := repy.ReadSelection(f)
+		f.Close()
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %q", filename)
+		}
+		selections = append(selections, s)
+	}
+
+	catalog, err := readREPYFile(*inputFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read REPY input")
+	}
+
+	slots, missing := catalog.FreeTime(selections, opts)
+	for _, ref := range missing {
+		fmt.Fprintf(os.Stderr, "Warning: group %d of course %s isn't in the catalog\n", ref.GroupID, ref.CourseID)
+	}
+
+	if *asJSON {
+		if *clockTimes {
+			clockSlots := make([]repy.ClockInterval, len(slots))
+			for i, s := range slots {
+				clockSlots[i] = s.Clock()
+			}
+			return writeJSONFile(*outputFile, clockSlots)
+		}
+		return writeJSONFile(*outputFile, slots)
+	}
+
+	f, err := os.Create(*outputFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	for _, s := range slots {
+		fmt.Fprintf(f, "%-9s %s-%s (%d minutes)\n", s.Day, s.Start, s.End, s.Length())
+	}
+	return nil
+}
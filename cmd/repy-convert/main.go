@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -10,13 +11,50 @@ import (
 )
 
 var (
-	inputFile  = flag.String("input_file", "/dev/stdin", "File to read for input")
-	outputFile = flag.String("output_file", "/dev/stdout", "File to read for output")
+	inputFile   = flag.String("input_file", "/dev/stdin", "File to read for input")
+	outputFile  = flag.String("output_file", "/dev/stdout", "File to read for output")
+	fromZip     = flag.String("from_zip", "", "If set, read input from this REPFILE.zip-style archive instead of input_file")
+	zipMembers  = flag.String("zip_members", "*", "Pattern of archive members to parse with from_zip; members not in REPY format are skipped")
+	listZip     = flag.Bool("list_zip", false, "List the members of the from_zip archive instead of parsing them")
+	concurrency = flag.Int("concurrency", 1, "Amount of faculties to parse in parallel")
 )
 
+// commands are subcommands of repy-convert, selected by the first argument.
+// Each is given the remaining arguments. Without a subcommand, repy-convert
+// converts a REPY file to JSON.
+var commands = map[string]func(args []string) error{
+	"exams":     examsMain,
+	"freetime":  freetimeMain,
+	"merge":     mergeMain,
+	"plan":      planMain,
+	"site":      siteMain,
+	"timetable": timetableMain,
+	"validate":  validateMain,
+	"walking":   walkingMain,
+	"watch":     watchMain,
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		if cmd, ok := commands[os.Args[1]]; ok {
+			if err := cmd(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", os.Args[1], err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	flag.Parse()
 
+	if *fromZip != "" {
+		if err := convertZip(); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to convert %q: %v\n", *fromZip, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	catalog, err := readREPYFile(*inputFile)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to read REPY input: %v\n", err)
@@ -35,10 +73,42 @@ func readREPYFile(filename string) (*repy.Catalog, error) {
 	}
 	defer f.Close()
 
-	return repy.ReadFile(f, repy.GLogger{})
+	return repy.ReadFileContext(context.Background(), f, readOptions())
+}
+
+func readOptions() repy.ReadOptions {
+	return repy.ReadOptions{Logger: repy.GLogger{}, Concurrency: *concurrency}
+}
+
+func convertZip() error {
+	f, err := os.Open(*fromZip)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	st, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	archive, err := repy.NewArchive(f, st.Size(), repy.DefaultArchiveLimits)
+	if err != nil {
+		return err
+	}
+
+	if *listZip {
+		return writeJSONFile(*outputFile, archive.Members())
+	}
+
+	catalog, err := archive.ParseAll(context.Background(), *zipMembers, readOptions())
+	if err != nil {
+		return err
+	}
+	return writeJSONFile(*outputFile, catalog)
 }
 
-func writeJSONFile(filename string, catalog *repy.Catalog) error {
+func writeJSONFile(filename string, v interface{}) error {
 	f, err := os.Create(filename)
 	if err != nil {
 		return err
@@ -47,5 +117,5 @@ func writeJSONFile(filename string, catalog *repy.Catalog) error {
 
 	enc := json.NewEncoder(f)
 	enc.SetIndent("", "  ")
-	return enc.Encode(catalog)
+	return enc.Encode(v)
 }
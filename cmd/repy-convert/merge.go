@@ -0,0 +1,42 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/lutzky/repy"
+	"github.com/pkg/errors"
+)
+
+// mergeMain implements "repy-convert merge", which merges the REPY files given
+// as arguments into a single JSON catalog using repy.Merge.
+func mergeMain(args []string) error {
+	fs := flag.NewFlagSet("merge", flag.ExitOnError)
+	outputFile := fs.String("output_file", "/dev/stdout", "File to write the merged catalog to")
+	reportFile := fs.String("report_file", "", "If set, write the JSON merge report (cross-listed and conflicting courses) to this file")
+	fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		return errors.New("no REPY files given")
+	}
+
+	var catalogs []*repy.Catalog
+	for _, filename := range fs.Args() {
+		c, err := readREPYFile(filename)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %q", filename)
+		}
+		catalogs = append(catalogs, c)
+	}
+
+	merged, report := repy.Merge(catalogs...)
+	fmt.Fprintf(os.Stderr, "%d cross-listed courses, %d conflicts\n", len(report.CrossListed), len(report.Conflicts))
+
+	if *reportFile != "" {
+		if err := writeJSONFile(*reportFile, report); err != nil {
+			return err
+		}
+	}
+	return writeJSONFile(*outputFile, merged)
+}
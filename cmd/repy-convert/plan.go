@@ -0,0 +1,87 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/lutzky/repy"
+	"github.com/pkg/errors"
+)
+
+func readPlanFile(filename string) (*repy.Plan, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return repy.ReadPlan(f)
+}
+
+// planMain implements "repy-convert plan", which validates a semester of a
+// plan file using repy.ValidatePlan, failing if there are any problems. With
+// -previous_file, only problems which the previous REPY version didn't have
+// are reported.
+func planMain(args []string) error {
+	fs := flag.NewFlagSet("plan", flag.ExitOnError)
+	inputFile := fs.String("input_file", "/dev/stdin", "REPY file to read")
+	planFile := fs.String("plan_file", "", "Plan file, in YAML or JSON format")
+	semester := fs.String("semester", "", "Semester of the plan to validate; defaults to that of the REPY file")
+	previousFile := fs.String("previous_file", "", "If set, only report problems which the plan didn't have against this previous REPY file")
+	outputFile := fs.String("output_file", "/dev/stdout", "File to write the report to")
+	asJSON := fs.Bool("json", false, "Write the report as JSON")
+	fs.Parse(args)
+
+	if *planFile == "" {
+		return errors.New("-plan_file is required")
+	}
+	plan, err := readPlanFile(*planFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read plan from %q", *planFile)
+	}
+
+	catalog, err := readREPYFile(*inputFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read REPY input")
+	}
+
+	if *semester == "" && len(*catalog) > 0 {
+		*semester = (*catalog)[0].Semester
+	}
+	s := plan.Semester(*semester)
+	if s == nil {
+		return errors.Errorf("plan has no semester %q", *semester)
+	}
+
+	report := repy.ValidatePlan(catalog, *s)
+	if *previousFile != "" {
+		previous, err := readREPYFile(*previousFile)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read previous REPY file")
+		}
+		report.Problems = repy.NewPlanProblems(previous, catalog, *s)
+	}
+
+	if *asJSON {
+		if err := writeJSONFile(*outputFile, report); err != nil {
+			return err
+		}
+	} else {
+		f, err := os.Create(*outputFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		fmt.Fprintf(f, "%s: %.1f academic points, weekly hours: %d lecture, %d tutorial, %d lab, %d project\n",
+			report.Semester, report.AcademicPoints,
+			report.WeeklyHours.Lecture, report.WeeklyHours.Tutorial, report.WeeklyHours.Lab, report.WeeklyHours.Project)
+		for _, p := range report.Problems {
+			fmt.Fprintln(f, p)
+		}
+	}
+
+	if len(report.Problems) > 0 {
+		return errors.Errorf("found %d problems", len(report.Problems))
+	}
+	return nil
+}
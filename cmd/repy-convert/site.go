@@ -0,0 +1,32 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/lutzky/repy/site"
+	"github.com/pkg/errors"
+)
+
+// siteMain implements "repy-convert site", which renders a static HTML site
+// for browsing the catalog.
+func siteMain(args []string) error {
+	fs := flag.NewFlagSet("site", flag.ExitOnError)
+	inputFile := fs.String("input_file", "/dev/stdin", "REPY file to read")
+	outputDir := fs.String("output_dir", "", "Directory to write the site into")
+	title := fs.String("title", "", "Title of the site; defaults to the catalog's semester")
+	fs.Parse(args)
+
+	if *outputDir == "" {
+		return errors.New("-output_dir is required")
+	}
+
+	catalog, err := readREPYFile(*inputFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read REPY input")
+	}
+
+	if *title == "" && len(*catalog) > 0 {
+		*title = (*catalog)[0].Semester
+	}
+	return site.Write(catalog, *outputDir, *title)
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"strconv"
+
+	"github.com/lutzky/repy"
+	"github.com/lutzky/repy/render"
+	"github.com/pkg/errors"
+)
+
+// chosenGroups collects the -course and -group flags of "repy-convert
+// timetable": each -group refers to the last -course before it, and courses
+// without any -group stand for all of their groups.
+type chosenGroups struct {
+	courses []repy.CourseID
+	refs    repy.Selection
+}
+
+type courseFlag struct{ *chosenGroups }
+
+func (f courseFlag) String() string { return "" }
+
+func (f courseFlag) Set(s string) error {
+	id, err := repy.ParseCourseID(s)
+	if err != nil {
+		return err
+	}
+	f.courses = append(f.courses, id)
+	return nil
+}
+
+type groupFlag struct{ *chosenGroups }
+
+func (f groupFlag) String() string { return "" }
+
+func (f groupFlag) Set(s string) error {
+	if len(f.courses) == 0 {
+		return errors.New("-group must follow -course")
+	}
+	id, err := strconv.ParseUint(s, 10, 0)
+	if err != nil {
+		return errors.Errorf("invalid group %q", s)
+	}
+	f.refs = append(f.refs, repy.GroupRef{CourseID: f.courses[len(f.courses)-1], GroupID: uint(id)})
+	return nil
+}
+
+// selection returns the chosen groups, looking up courses without any -group
+// in c.
+func (g *chosenGroups) selection(c *repy.Catalog) (repy.Selection, error) {
+	result := g.refs
+	for _, id := range g.courses {
+		hasGroups := false
+		for _, ref := range g.refs {
+			hasGroups = hasGroups || ref.CourseID == id
+		}
+		if hasGroups {
+			continue
+		}
+		course := c.Course(id)
+		if course == nil {
+			return nil, errors.Errorf("no such course %s", id)
+		}
+		for _, group := range course.Groups {
+			result = append(result, repy.GroupRef{CourseID: id, GroupID: group.ID})
+		}
+	}
+	return result, nil
+}
+
+// timetableMain implements "repy-convert timetable", which prints a weekly
+// timetable of the chosen groups, e.g.:
+//
+//	repy-convert timetable -input_file REPY -course 14003 -group 10 -group 11 -course 234114
+func timetableMain(args []string) error {
+	fs := flag.NewFlagSet("timetable", flag.ExitOnError)
+	inputFile := fs.String("input_file", "/dev/stdin", "REPY file to read")
+	selectionFile := fs.String("selection_file", "", "If set, also show the groups in this selection file, e.g. as saved by repy-tui")
+	width := fs.Int("width", 80, "Width of the timetable, in columns")
+	terminalBidi := fs.Bool("terminal_bidi", false, "Whether the terminal displays Hebrew right-to-left by itself; if not, Hebrew text is reordered for display")
+	failOnClash := fs.Bool("fail_on_clash", false, "Exit with an error if chosen events overlap")
+	var chosen chosenGroups
+	fs.Var(courseFlag{&chosen}, "course", "Course to show; may be repeated")
+	fs.Var(groupFlag{&chosen}, "group", "Group of the preceding -course to show; may be repeated. Without any, all groups of the course are shown")
+	fs.Parse(args)
+
+	catalog, err := readREPYFile(*inputFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read REPY input")
+	}
+
+	selection, err := chosen.selection(catalog)
+	if err != nil {
+		return err
+	}
+	if *selectionFile != "" {
+		f, err := os.Open(*selectionFile)
+		if err != nil {
+			return err
+		}
+		s, err := repy.ReadSelection(f)
+		f.Close()
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %q", *selectionFile)
+		}
+		selection = append(selection, s...)
+	}
+	if len(selection) == 0 {
+		return errors.New("no groups chosen; use -course and -group, or -selection_file")
+	}
+
+	events, missing := catalog.Resolve(selection)
+	if len(missing) > 0 {
+		return errors.Errorf("no such group %d of course %s", missing[0].GroupID, missing[0].CourseID)
+	}
+
+	clashes, err := render.Text(os.Stdout, render.Scheduled(events), render.TextOptions{
+		Width:  *width,
+		Visual: !*terminalBidi,
+	})
+	if err != nil {
+		return err
+	}
+	if *failOnClash && len(clashes) > 0 {
+		return errors.Errorf("found %d clashes", len(clashes))
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/lutzky/repy"
+	"github.com/pkg/errors"
+)
+
+// validateMain implements "repy-convert validate", which reports problems
+// found by repy.Validate, failing if there are any.
+func validateMain(args []string) error {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	inputFile := fs.String("input_file", "/dev/stdin", "REPY file to read")
+	outputFile := fs.String("output_file", "/dev/stdout", "File to write problems to")
+	asJSON := fs.Bool("json", false, "Write problems as JSON")
+	fs.Parse(args)
+
+	catalog, err := readREPYFile(*inputFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read REPY input")
+	}
+
+	problems := repy.Validate(catalog)
+
+	if *asJSON {
+		if err := writeJSONFile(*outputFile, problems); err != nil {
+			return err
+		}
+	} else {
+		f, err := os.Create(*outputFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		for _, p := range problems {
+			fmt.Fprintln(f, p)
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.Errorf("found %d problems", len(problems))
+	}
+	return nil
+}
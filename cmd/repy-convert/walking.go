@@ -0,0 +1,88 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/lutzky/repy"
+	"github.com/pkg/errors"
+)
+
+// walkingMain implements "repy-convert walking", which reports consecutive
+// events in each of the given selection files whose gap is shorter than the
+// walking time between their buildings, failing if there are any.
+func walkingMain(args []string) error {
+	fs := flag.NewFlagSet("walking", flag.ExitOnError)
+	inputFile := fs.String("input_file", "/dev/stdin", "REPY file to read")
+	buildingsFile := fs.String("buildings_file", "", "YAML file of building coordinates and walking times")
+	outputFile := fs.String("output_file", "/dev/stdout", "File to write the report to")
+	asJSON := fs.Bool("json", false, "Write the report as JSON")
+	fs.Parse(args)
+
+	if *buildingsFile == "" {
+		return errors.New("-buildings_file is required")
+	}
+	if fs.NArg() == 0 {
+		return errors.New("no selection files given")
+	}
+
+	f, err := os.Open(*buildingsFile)
+	if err != nil {
+		return err
+	}
+	walking, err := repy.ReadWalkingTimes(f)
+	f.Close()
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %q", *buildingsFile)
+	}
+
+	catalog, err := readREPYFile(*inputFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read REPY input")
+	}
+
+	report := map[string][]repy.TightConnection{}
+	total := 0
+	for _, filename := range fs.Args() {
+		f, err := os.Open(filename)
+		if err != nil {
+			return err
+		}
+		s, err := repy.ReadSelection(f)
+		f.Close()
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %q", filename)
+		}
+		events, missing := catalog.Resolve(s)
+		for _, ref := range missing {
+			fmt.Fprintf(os.Stderr, "Warning: %s: group %d of course %s isn't in the catalog\n", filename, ref.GroupID, ref.CourseID)
+		}
+		report[filename] = walking.TightConnections(events)
+		total += len(report[filename])
+	}
+
+	if *asJSON {
+		if err := writeJSONFile(*outputFile, report); err != nil {
+			return err
+		}
+	} else {
+		out, err := os.Create(*outputFile)
+		if err != nil {
+			return err
+		}
+		defer out.Close()
+		for _, filename := range fs.Args() {
+			for _, c := range report[filename] {
+				fmt.Fprintf(out, "%s: %s: course %s group %d (%s) to course %s group %d (%s): %d minutes apart, walking takes %d\n",
+					filename, c.Day, c.From.CourseID, c.From.GroupID, c.FromLocation,
+					c.To.CourseID, c.To.GroupID, c.ToLocation, c.GapMinutes, c.WalkingMinutes)
+			}
+		}
+	}
+
+	if total > 0 {
+		return errors.Errorf("found %d tight connections", total)
+	}
+	return nil
+}
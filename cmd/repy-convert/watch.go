@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/lutzky/repy"
+	"github.com/lutzky/repy/fetch"
+	"github.com/lutzky/repy/publish"
+	"github.com/pkg/errors"
+)
+
+// watchMain implements "repy-convert watch", which polls a REPFILE.zip URL
+// and publishes new REPY versions into a local directory, in the same layout
+// as the AppEngine app.
+func watchMain(args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	url := fs.String("url", repy.RepFileURL, "URL of REPFILE.zip")
+	dir := fs.String("dir", "", "Directory to publish into")
+	interval := fs.Duration("interval", time.Hour, "How often to poll url")
+	hook := fs.String("hook", "", "Shell command to run after publishing a new version. "+
+		"REPY_SHA1SUM and REPY_DIR are set in its environment.")
+	once := fs.Bool("once", false, "Poll once and exit")
+	fs.Parse(args)
+
+	if *dir == "" {
+		return errors.New("-dir is required")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	w := watcher{
+		url:     *url,
+		dir:     *dir,
+		hook:    *hook,
+		fetcher: &fetch.Fetcher{},
+		store:   publish.Dir(*dir),
+	}
+
+	for {
+		err := w.poll(ctx)
+		if *once {
+			return err
+		}
+		if err != nil {
+			log.Printf("Failed to update from %s: %v", *url, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(*interval):
+		}
+	}
+}
+
+type watcher struct {
+	url, dir, hook string
+	fetcher        *fetch.Fetcher
+	store          publish.Store
+
+	// unhooked is the SHA1 sum of a REPY file for which publishing or the
+	// hook failed, so the hook should run once it is published, even though
+	// it is no longer new.
+	unhooked string
+}
+
+func (w *watcher) poll(ctx context.Context) error {
+	result, err := w.fetcher.Fetch(ctx, w.url)
+	if err != nil {
+		return err
+	}
+	// The file is published even if it wasn't modified, as the previous
+	// attempt to publish it might have failed. Publish is cheap for files
+	// which were already published.
+	if result.NotModified {
+		log.Printf("%s not modified", w.url)
+	}
+
+	repyBytes, err := repy.ExtractFromZip(bytes.NewReader(result.Body))
+	if err != nil {
+		return err
+	}
+
+	published, err := publish.Publish(ctx, w.store, repyBytes, publish.Options{
+		Logger: repy.SlogLogger{Handler: slog.Default().Handler()},
+	})
+	if err != nil {
+		w.unhooked = fmt.Sprintf("%x", sha1.Sum(repyBytes))
+		return err
+	}
+
+	switch {
+	case published.New:
+		log.Printf("Published new REPY %s", published.SHA1Sum)
+	case published.SHA1Sum == w.unhooked:
+		log.Printf("Finished publishing REPY %s", published.SHA1Sum)
+	default:
+		log.Printf("REPY %s already published", published.SHA1Sum)
+		return nil
+	}
+
+	if w.hook != "" {
+		if err := w.runHook(ctx, published.SHA1Sum); err != nil {
+			w.unhooked = published.SHA1Sum
+			return errors.Wrapf(err, "post-update hook %q failed", w.hook)
+		}
+	}
+	w.unhooked = ""
+	return nil
+}
+
+func (w *watcher) runHook(ctx context.Context, sha1sum string) error {
+	cmd := exec.CommandContext(ctx, "sh", "-c", w.hook)
+	cmd.Env = append(os.Environ(), "REPY_SHA1SUM="+sha1sum, "REPY_DIR="+w.dir)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
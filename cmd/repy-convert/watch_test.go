@@ -0,0 +1,140 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lutzky/repy"
+	"github.com/lutzky/repy/fetch"
+	"github.com/lutzky/repy/publish"
+	"github.com/pkg/errors"
+)
+
+// flakyStore fails the first write of failName.
+type flakyStore struct {
+	publish.Dir
+	failName string
+	failed   bool
+}
+
+func (s *flakyStore) Write(ctx context.Context, name, contentType string, data []byte) error {
+	if name == s.failName && !s.failed {
+		s.failed = true
+		return errors.Errorf("failed to write %q", name)
+	}
+	return s.Dir.Write(ctx, name, contentType, data)
+}
+
+// repFileServer serves a REPFILE.zip containing the named testdata file, with
+// an ETag, so that repeated requests get 304 Not Modified.
+func repFileServer(t *testing.T, name string) *httptest.Server {
+	t.Helper()
+	data, err := ioutil.ReadFile(filepath.Join("../../testdata", name))
+	if err != nil {
+		t.Fatalf("Couldn't read %q: %v", name, err)
+	}
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	fw, err := zw.Create(repy.REPYFileName)
+	if err != nil {
+		t.Fatalf("Failed to create zip: %v", err)
+	}
+	fw.Write(data)
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Failed to create zip: %v", err)
+	}
+
+	const etag = `"v1"`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Write(buf.Bytes())
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestWatcherRetriesFailures(t *testing.T) {
+	testCases := []struct {
+		name string
+		// failName is written by Publish, and fails once.
+		failName string
+		// failHook makes the hook fail the first time it runs.
+		failHook bool
+		// wantErrs and wantHookRuns are per poll; hook runs are cumulative.
+		wantErrs     []bool
+		wantHookRuns []int
+	}{
+		{
+			name:         "publish fails once",
+			failName:     "latest.json",
+			wantErrs:     []bool{true, false, false},
+			wantHookRuns: []int{0, 1, 1},
+		},
+		{
+			name:         "hook fails once",
+			failHook:     true,
+			wantErrs:     []bool{true, false, false},
+			wantHookRuns: []int{1, 2, 2},
+		},
+		{
+			name:         "no failures",
+			wantErrs:     []bool{false, false},
+			wantHookRuns: []int{1, 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			srv := repFileServer(t, "course_statistics.repy")
+			dir := t.TempDir()
+			hookLog := filepath.Join(t.TempDir(), "hook.log")
+
+			hook := `echo "$REPY_SHA1SUM" >> ` + hookLog
+			if tc.failHook {
+				marker := filepath.Join(t.TempDir(), "failed")
+				hook += ` && { test -e ` + marker + ` || { touch ` + marker + `; exit 1; }; }`
+			}
+
+			w := watcher{
+				url:     srv.URL,
+				dir:     dir,
+				hook:    hook,
+				fetcher: &fetch.Fetcher{Retries: -1},
+				store:   &flakyStore{Dir: publish.Dir(dir), failName: tc.failName},
+			}
+
+			for i, wantErr := range tc.wantErrs {
+				err := w.poll(ctx)
+				if gotErr := err != nil; gotErr != wantErr {
+					t.Fatalf("Poll %d returned error %v; want error: %t", i, err, wantErr)
+				}
+				hookRuns := 0
+				if b, err := ioutil.ReadFile(hookLog); err == nil {
+					hookRuns = len(strings.Fields(string(b)))
+				} else if !os.IsNotExist(err) {
+					t.Fatalf("Failed to read hook log: %v", err)
+				}
+				if hookRuns != tc.wantHookRuns[i] {
+					t.Errorf("After poll %d, hook ran %d times; want %d", i, hookRuns, tc.wantHookRuns[i])
+				}
+			}
+
+			if _, err := os.Stat(filepath.Join(dir, "latest.json")); err != nil {
+				t.Errorf("latest.json wasn't published: %v", err)
+			}
+		})
+	}
+}
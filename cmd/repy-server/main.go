@@ -0,0 +1,89 @@
+// Command repy-server serves the catalogs published into a local directory
+// (e.g. by "repy-convert watch") over GraphQL and, optionally, gRPC.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/lutzky/repy/graphql"
+	"github.com/lutzky/repy/publish"
+	"github.com/lutzky/repy/rpc"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+var (
+	dir        = flag.String("dir", "", "Directory of published catalogs, as written by repy-convert watch")
+	listen     = flag.String("listen", ":8080", "Address to serve GraphQL on")
+	grpcListen = flag.String("grpc_listen", "", "If set, address to serve gRPC on")
+)
+
+func main() {
+	flag.Parse()
+	if err := serve(); err != nil {
+		fmt.Fprintf(os.Stderr, "repy-server: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func serve() error {
+	if *dir == "" {
+		return errors.New("-dir is required")
+	}
+
+	store := publish.Dir(*dir)
+	handler, err := graphql.NewHandler(store)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/graphql", handler)
+	server := &http.Server{Addr: *listen, Handler: mux}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errc := make(chan error, 2)
+	go func() {
+		log.Printf("Serving GraphQL for %s on %s/graphql", *dir, *listen)
+		errc <- errors.Wrap(server.ListenAndServe(), "failed to serve GraphQL")
+	}()
+
+	var grpcServer *grpc.Server
+	if *grpcListen != "" {
+		lis, err := net.Listen("tcp", *grpcListen)
+		if err != nil {
+			return errors.Wrap(err, "failed to listen for gRPC")
+		}
+		grpcServer = grpc.NewServer()
+		rpc.RegisterCatalogServiceServer(grpcServer, rpc.NewServer(store))
+		go func() {
+			log.Printf("Serving gRPC for %s on %s", *dir, *grpcListen)
+			errc <- errors.Wrap(grpcServer.Serve(lis), "failed to serve gRPC")
+		}()
+	}
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	if grpcServer != nil {
+		// Streams such as WatchVersions never end by themselves.
+		grpcServer.Stop()
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return server.Shutdown(shutdownCtx)
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lutzky/repy"
+	"github.com/mattn/go-runewidth"
+)
+
+// gridStep is the length, in minutes, of each row of the schedule grid.
+const gridStep = 30
+
+var gridDays = []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+
+// pad pads s, which is already in display form, with spaces to width columns.
+func pad(s string, width int) string {
+	if w := runewidth.StringWidth(s); w < width {
+		return s + strings.Repeat(" ", width-w)
+	}
+	return s
+}
+
+// gridRow is a row of the schedule grid, with the names of the courses taking
+// place during it on each of gridDays. Cells with more than one name are
+// clashes.
+type gridRow struct {
+	Time  repy.MinutesSinceMidnight
+	Cells [][]string
+}
+
+// newGrid lays out events in rows of gridStep minutes, from the start of the
+// earliest event (rounded down to gridStep) to the end of the latest one.
+func newGrid(events []repy.ScheduledEvent) []gridRow {
+	if len(events) == 0 {
+		return nil
+	}
+	start, end := events[0].Event.StartMinute, events[0].Event.EndMinute
+	for _, ev := range events {
+		if ev.Event.StartMinute < start {
+			start = ev.Event.StartMinute
+		}
+		if ev.Event.EndMinute > end {
+			end = ev.Event.EndMinute
+		}
+	}
+	start -= start % gridStep
+
+	var rows []gridRow
+	for t := start; t < end; t += gridStep {
+		row := gridRow{Time: t, Cells: make([][]string, len(gridDays))}
+		for i, day := range gridDays {
+			for _, ev := range events {
+				if ev.Event.Interval().Overlaps(repy.WeeklyInterval{Day: day, Start: t, End: t + gridStep}) {
+					row.Cells[i] = append(row.Cells[i], ev.Course.Name)
+				}
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func (m *model) viewSchedule(b *strings.Builder) {
+	events, missing := m.catalog.Resolve(m.selection)
+	clashes := repy.Clashes(events)
+
+	b.WriteString(titleStyle.Render(m.text(fmt.Sprintf("Schedule (%d groups, %d clashes)", len(m.selection), len(clashes)), m.width)) + "\n\n")
+	if len(events) == 0 {
+		b.WriteString("No groups selected; select groups from a course's page.\n")
+	}
+	for _, ref := range missing {
+		b.WriteString(clashStyle.Render(fmt.Sprintf("Group %d of course %s isn't in the catalog", ref.GroupID, ref.CourseID)) + "\n")
+	}
+	if len(events) == 0 {
+		return
+	}
+
+	const timeWidth = 6
+	colWidth := (m.width - timeWidth) / len(gridDays)
+	if colWidth < 4 {
+		colWidth = 4
+	}
+
+	b.WriteString(strings.Repeat(" ", timeWidth))
+	for _, day := range gridDays {
+		b.WriteString(pad(day.String()[:3], colWidth))
+	}
+	b.WriteString("\n")
+
+	for _, row := range newGrid(events) {
+		b.WriteString(pad(row.Time.String(), timeWidth))
+		for _, names := range row.Cells {
+			cell := m.text(strings.Join(names, "/"), colWidth-1)
+			cell = pad(cell, colWidth)
+			switch {
+			case len(names) > 1:
+				cell = clashStyle.Render(cell)
+			case len(names) == 1:
+				cell = selectedStyle.Render(cell)
+			}
+			b.WriteString(cell)
+		}
+		b.WriteString("\n")
+	}
+
+	for _, c := range clashes {
+		b.WriteString(clashStyle.Render(m.text(fmt.Sprintf("Clash on %s: %s group %d (%s-%s) and %s group %d (%s-%s)",
+			c.First.Event.Day, c.First.Course.Name, c.First.Group.ID, c.First.Event.StartMinute, c.First.Event.EndMinute,
+			c.Second.Course.Name, c.Second.Group.ID, c.Second.Event.StartMinute, c.Second.Event.EndMinute), m.width)) + "\n")
+	}
+}
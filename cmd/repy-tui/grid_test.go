@@ -0,0 +1,89 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lutzky/repy"
+)
+
+func TestGrid(t *testing.T) {
+	algebra := &repy.Course{ID: "104166", Name: "algebra"}
+	physics := &repy.Course{ID: "114051", Name: "physics"}
+	event := func(c *repy.Course, day time.Weekday, start, end repy.MinutesSinceMidnight) repy.ScheduledEvent {
+		return repy.ScheduledEvent{Course: c, Group: &repy.Group{ID: 10}, Event: repy.Event{Day: day, StartMinute: start, EndMinute: end}}
+	}
+
+	testCases := []struct {
+		name      string
+		events    []repy.ScheduledEvent
+		wantStart repy.MinutesSinceMidnight
+		// wantCells are the cells of each row, by day index in gridDays.
+		wantCells []map[int][]string
+	}{
+		{
+			name:      "no events",
+			events:    nil,
+			wantCells: nil,
+		},
+		{
+			name:      "rounded start",
+			events:    []repy.ScheduledEvent{event(algebra, time.Sunday, 525, 600)},
+			wantStart: 510,
+			wantCells: []map[int][]string{
+				{0: {"algebra"}},
+				{0: {"algebra"}},
+				{0: {"algebra"}},
+			},
+		},
+		{
+			name: "clash",
+			events: []repy.ScheduledEvent{
+				event(algebra, time.Monday, 600, 690),
+				event(physics, time.Monday, 660, 720),
+			},
+			wantStart: 600,
+			wantCells: []map[int][]string{
+				{1: {"algebra"}},
+				{1: {"algebra"}},
+				{1: {"algebra", "physics"}},
+				{1: {"physics"}},
+			},
+		},
+		{
+			name: "back to back on different days",
+			events: []repy.ScheduledEvent{
+				event(algebra, time.Tuesday, 600, 630),
+				event(physics, time.Tuesday, 630, 660),
+				event(physics, time.Friday, 600, 660),
+			},
+			wantStart: 600,
+			wantCells: []map[int][]string{
+				{2: {"algebra"}, 5: {"physics"}},
+				{2: {"physics"}, 5: {"physics"}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rows := newGrid(tc.events)
+			if len(rows) != len(tc.wantCells) {
+				t.Fatalf("Got %d rows; want %d", len(rows), len(tc.wantCells))
+			}
+			for i, row := range rows {
+				if want := tc.wantStart + repy.MinutesSinceMidnight(i*gridStep); row.Time != want {
+					t.Errorf("Row %d at %s; want %s", i, row.Time, want)
+				}
+				want := make([][]string, len(gridDays))
+				for day, names := range tc.wantCells[i] {
+					want[day] = names
+				}
+				if d := cmp.Diff(want, row.Cells); d != "" {
+					t.Errorf("Row %d at %s diff -want +got:\n%s", i, row.Time, d)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Command repy-tui is an interactive terminal browser for REPY catalogs. It
+// lets users browse and search courses, view their groups, and build a
+// tentative weekly schedule, which is saved as a selection file.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lutzky/repy"
+	"github.com/lutzky/repy/bidi"
+	"github.com/pkg/errors"
+)
+
+var (
+	inputFile     = flag.String("input_file", "", "REPY file, or catalog in JSON format, to browse")
+	selectionFile = flag.String("selection_file", "selection.json", "File to load the selection from, if it exists, and save it to")
+	terminalBidi  = flag.Bool("terminal_bidi", false, "Whether the terminal displays Hebrew right-to-left by itself; if not, Hebrew text is reordered for display")
+)
+
+func main() {
+	flag.Parse()
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "repy-tui: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	if *inputFile == "" {
+		return errors.New("-input_file is required")
+	}
+	catalog, err := readCatalog(*inputFile)
+	if err != nil {
+		return err
+	}
+
+	selection, err := readSelection(*selectionFile)
+	if err != nil {
+		return err
+	}
+
+	display := bidi.Visual
+	if *terminalBidi {
+		display = func(s string) string { return s }
+	}
+
+	m := newModel(catalog, selection, *selectionFile, display)
+	_, err = tea.NewProgram(m, tea.WithAltScreen()).Run()
+	return err
+}
+
+// readCatalog reads either a REPY file or a catalog in JSON format.
+func readCatalog(filename string) (*repy.Catalog, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		var c repy.Catalog
+		if err := json.Unmarshal(data, &c); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal %q", filename)
+		}
+		return &c, nil
+	}
+	c, err := repy.ReadFileContext(context.Background(), bytes.NewReader(data), repy.ReadOptions{})
+	return c, errors.Wrapf(err, "failed to parse %q", filename)
+}
+
+func readSelection(filename string) (repy.Selection, error) {
+	f, err := os.Open(filename)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	s, err := repy.ReadSelection(f)
+	return s, errors.Wrapf(err, "failed to read %q", filename)
+}
+
+func writeSelection(filename string, s repy.Selection) error {
+	var buf bytes.Buffer
+	if err := repy.WriteSelection(&buf, s); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filename, buf.Bytes(), 0644)
+}
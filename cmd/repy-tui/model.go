@@ -0,0 +1,387 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lutzky/repy"
+	"github.com/mattn/go-runewidth"
+)
+
+type view int
+
+const (
+	facultiesView view = iota
+	coursesView
+	courseView
+	scheduleView
+)
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true)
+	cursorStyle   = lipgloss.NewStyle().Reverse(true)
+	clashStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Bold(true)
+	selectedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+	helpStyle     = lipgloss.NewStyle().Faint(true)
+)
+
+// courseEntry is a course in a list, along with its faculty.
+type courseEntry struct {
+	faculty *repy.Faculty
+	course  *repy.Course
+}
+
+type model struct {
+	catalog       *repy.Catalog
+	selection     repy.Selection
+	selectionFile string
+
+	// display converts text for display, e.g. reordering Hebrew for terminals
+	// without bidi support.
+	display func(string) string
+
+	view    view
+	history []view
+
+	// cursors and offsets are per view, so that going back keeps the
+	// position.
+	cursors map[view]int
+	offsets map[view]int
+
+	courses      []courseEntry
+	coursesTitle string
+	course       courseEntry
+
+	searching bool
+	query     string
+
+	status        string
+	width, height int
+}
+
+func newModel(catalog *repy.Catalog, selection repy.Selection, selectionFile string, display func(string) string) *model {
+	return &model{
+		catalog:       catalog,
+		selection:     selection,
+		selectionFile: selectionFile,
+		display:       display,
+		cursors:       map[view]int{},
+		offsets:       map[view]int{},
+		width:         80,
+		height:        24,
+	}
+}
+
+func (m *model) Init() tea.Cmd {
+	return nil
+}
+
+// text fits s, in logical order, into width columns and converts it for
+// display.
+func (m *model) text(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	return m.display(runewidth.Truncate(s, width, "…"))
+}
+
+func (m *model) open(v view) {
+	m.history = append(m.history, m.view)
+	m.view = v
+	m.cursors[v] = 0
+	m.offsets[v] = 0
+}
+
+func (m *model) back() {
+	if n := len(m.history); n > 0 {
+		m.view = m.history[n-1]
+		m.history = m.history[:n-1]
+	}
+}
+
+// listLen returns the amount of items in the list of the current view.
+func (m *model) listLen() int {
+	switch m.view {
+	case facultiesView:
+		return len(*m.catalog)
+	case coursesView:
+		return len(m.courses)
+	case courseView:
+		return len(m.course.course.Groups)
+	}
+	return 0
+}
+
+// listHeight is the amount of list items which fit on the screen.
+func (m *model) listHeight() int {
+	const header, footer = 2, 2
+	if h := m.height - header - footer; h > 1 {
+		return h
+	}
+	return 1
+}
+
+func (m *model) moveCursor(delta int) {
+	n := m.listLen()
+	if n == 0 {
+		return
+	}
+	c := m.cursors[m.view] + delta
+	if c < 0 {
+		c = 0
+	}
+	if c >= n {
+		c = n - 1
+	}
+	m.cursors[m.view] = c
+
+	h := m.listHeight()
+	if c < m.offsets[m.view] {
+		m.offsets[m.view] = c
+	} else if c >= m.offsets[m.view]+h {
+		m.offsets[m.view] = c - h + 1
+	}
+}
+
+func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+	case tea.KeyMsg:
+		if m.searching {
+			return m, m.updateSearch(msg)
+		}
+		return m, m.updateKey(msg)
+	}
+	return m, nil
+}
+
+func (m *model) updateSearch(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.searching = false
+		m.search()
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.searching = false
+	case tea.KeyBackspace:
+		if r := []rune(m.query); len(r) > 0 {
+			m.query = string(r[:len(r)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.query += string(msg.Runes)
+	}
+	return nil
+}
+
+// search lists the courses whose name or ID contains the query.
+func (m *model) search() {
+	q := strings.TrimSpace(m.query)
+	if q == "" {
+		return
+	}
+	var results []courseEntry
+	for i := range *m.catalog {
+		f := &(*m.catalog)[i]
+		for j := range f.Courses {
+			c := &f.Courses[j]
+			if strings.Contains(c.Name, q) || strings.Contains(string(c.ID), q) {
+				results = append(results, courseEntry{f, c})
+			}
+		}
+	}
+	m.courses = results
+	m.coursesTitle = fmt.Sprintf("Search: %s (%d courses)", q, len(results))
+	m.open(coursesView)
+}
+
+func (m *model) updateKey(msg tea.KeyMsg) tea.Cmd {
+	m.status = ""
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return tea.Quit
+	case "esc", "backspace", "left", "h":
+		m.back()
+	case "up", "k":
+		m.moveCursor(-1)
+	case "down", "j":
+		m.moveCursor(1)
+	case "pgup":
+		m.moveCursor(-m.listHeight())
+	case "pgdown":
+		m.moveCursor(m.listHeight())
+	case "/":
+		m.searching = true
+		m.query = ""
+	case "s":
+		if m.view != scheduleView {
+			m.open(scheduleView)
+		}
+	case "w":
+		if err := writeSelection(m.selectionFile, m.selection); err != nil {
+			m.status = fmt.Sprintf("Failed to save: %v", err)
+		} else {
+			m.status = fmt.Sprintf("Saved %d groups to %s", len(m.selection), m.selectionFile)
+		}
+	case "enter", "right", "l", " ":
+		m.choose()
+	}
+	return nil
+}
+
+// choose acts on the item under the cursor.
+func (m *model) choose() {
+	c := m.cursors[m.view]
+	switch m.view {
+	case facultiesView:
+		if c >= len(*m.catalog) {
+			return
+		}
+		f := &(*m.catalog)[c]
+		m.courses = nil
+		for i := range f.Courses {
+			m.courses = append(m.courses, courseEntry{f, &f.Courses[i]})
+		}
+		m.coursesTitle = f.Name
+		m.open(coursesView)
+	case coursesView:
+		if c >= len(m.courses) {
+			return
+		}
+		m.course = m.courses[c]
+		m.open(courseView)
+	case courseView:
+		groups := m.course.course.Groups
+		if c >= len(groups) {
+			return
+		}
+		ref := repy.GroupRef{CourseID: m.course.course.ID, GroupID: groups[c].ID}
+		if m.selection.Toggle(ref) {
+			m.status = fmt.Sprintf("Added group %d", ref.GroupID)
+		} else {
+			m.status = fmt.Sprintf("Removed group %d", ref.GroupID)
+		}
+	}
+}
+
+func (m *model) View() string {
+	var b strings.Builder
+	switch m.view {
+	case facultiesView:
+		m.viewFaculties(&b)
+	case coursesView:
+		m.viewCourses(&b)
+	case courseView:
+		m.viewCourse(&b)
+	case scheduleView:
+		m.viewSchedule(&b)
+	}
+
+	b.WriteString("\n")
+	switch {
+	case m.searching:
+		b.WriteString("Search: " + m.display(m.query) + "█")
+	case m.status != "":
+		b.WriteString(m.text(m.status, m.width))
+	default:
+		b.WriteString(helpStyle.Render(m.text(
+			"↑/↓ move  enter open/toggle  esc back  / search  s schedule  w save  q quit", m.width)))
+	}
+	return b.String()
+}
+
+// viewList renders the visible part of the list of the current view, with
+// item rendering each line.
+func (m *model) viewList(b *strings.Builder, title string, item func(i, width int) string) {
+	b.WriteString(titleStyle.Render(m.text(title, m.width)) + "\n\n")
+	n := m.listLen()
+	if n == 0 {
+		b.WriteString("(empty)\n")
+	}
+	start := m.offsets[m.view]
+	for i := start; i < n && i < start+m.listHeight(); i++ {
+		line := item(i, m.width-2)
+		if i == m.cursors[m.view] {
+			line = cursorStyle.Render(line)
+		}
+		b.WriteString("  " + line + "\n")
+	}
+}
+
+func (m *model) viewFaculties(b *strings.Builder) {
+	m.viewList(b, "Faculties", func(i, width int) string {
+		f := (*m.catalog)[i]
+		return m.text(fmt.Sprintf("%s (%s, %d courses)", f.Name, f.Semester, len(f.Courses)), width)
+	})
+}
+
+func (m *model) viewCourses(b *strings.Builder) {
+	m.viewList(b, m.coursesTitle, func(i, width int) string {
+		c := m.courses[i].course
+		line := m.text(fmt.Sprintf("%s %s", c.ID, c.Name), width-2)
+		if m.hasSelectedGroup(c) {
+			return selectedStyle.Render("✓ " + line)
+		}
+		return "  " + line
+	})
+}
+
+func (m *model) hasSelectedGroup(c *repy.Course) bool {
+	for _, ref := range m.selection {
+		if ref.CourseID == c.ID {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *model) viewCourse(b *strings.Builder) {
+	c := m.course.course
+	title := fmt.Sprintf("%s %s — %.1f points, %s", c.ID, c.Name, c.AcademicPoints, m.course.faculty.Name)
+
+	selected, _ := m.catalog.Resolve(m.selection)
+
+	m.viewList(b, title, func(i, width int) string {
+		g := &c.Groups[i]
+		ref := repy.GroupRef{CourseID: c.ID, GroupID: g.ID}
+
+		var events []string
+		for _, ev := range g.Events {
+			events = append(events, fmt.Sprintf("%s %s-%s %s", ev.Day.String()[:3], ev.StartMinute, ev.EndMinute, ev.Location))
+		}
+		line := fmt.Sprintf("%2d %-8s %s", g.ID, g.Type, strings.Join(events, ", "))
+		if len(g.Teachers) > 0 {
+			line += " — " + strings.Join(g.Teachers, ", ")
+		}
+		if g.Description != "" {
+			line += " — " + g.Description
+		}
+
+		marker := "[ ] "
+		if m.selection.Contains(ref) {
+			marker = "[x] "
+		}
+		if clashesWith(g, ref, selected) {
+			return clashStyle.Render(marker + "! " + m.text(line, width-6))
+		}
+		if m.selection.Contains(ref) {
+			return selectedStyle.Render(marker + "  " + m.text(line, width-6))
+		}
+		return marker + "  " + m.text(line, width-6)
+	})
+}
+
+// clashesWith returns whether any of g's events overlaps selected events of
+// other groups.
+func clashesWith(g *repy.Group, ref repy.GroupRef, selected []repy.ScheduledEvent) bool {
+	for _, ev := range g.Events {
+		for _, s := range selected {
+			if s.Ref() != ref && ev.Overlaps(s.Event) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,195 @@
+package repy
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// splitFaculties returns the indices of lines at which faculties start, i.e.
+// the first separator line of faculty headers which follow a blank line. The
+// first chunk always starts at 0, so that any content before the first faculty
+// is parsed as it would be sequentially.
+func splitFaculties(lines []string) []int {
+	starts := []int{0}
+	for i := 0; i < len(lines); i++ {
+		if i > 0 && strings.TrimSpace(lines[i-1]) != "" {
+			continue
+		}
+		var headerLines int
+		switch {
+		case lines[i] == facultySep && i+3 < len(lines) && lines[i+3] == facultySep:
+			headerLines = 4
+		case lines[i] == sportsFacultySep && i+2 < len(lines) && lines[i+2] == sportsFacultySep:
+			headerLines = 3
+		default:
+			continue
+		}
+		if i > 0 {
+			starts = append(starts, i)
+		}
+		i += headerLines - 1
+	}
+	return starts
+}
+
+// chunkResult is the outcome of parsing a chunk of the input.
+type chunkResult struct {
+	catalog *Catalog
+	err     error
+	parser  *parser
+	logs    *bufferedLogger
+}
+
+// parseConcurrently reads the rest of p's input, splits it into faculties and
+// parses up to n of them in parallel. If any of them fails, the error of the
+// first one to fail is returned; its line numbers are those of the input, as
+// each chunk is parsed starting at its offset.
+func (p *parser) parseConcurrently(n int) (*Catalog, error) {
+	var lines []string
+	for {
+		line, ok := p.readLine()
+		if !ok {
+			break
+		}
+		p.line++
+		lines = append(lines, line)
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	starts := splitFaculties(lines)
+	results := make([]chunkResult, len(starts))
+	progress := newChunkProgress(p.progress, len(starts))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, n)
+	for i, start := range starts {
+		end := len(lines)
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+		r := &results[i]
+		var logger Logger
+		if p.logger != nil {
+			r.logs = &bufferedLogger{}
+			logger = r.logs
+		}
+		r.parser = p.chunkParser(lines[start:end], uint(start), logger)
+		r.parser.partial = end < len(lines)
+		r.parser.progress = progress.chunk(i, uint(start))
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			r.catalog, r.err = r.parser.parseFile()
+		}()
+	}
+	wg.Wait()
+
+	catalog := Catalog{}
+	for _, r := range results {
+		r.logs.replay(p.logger)
+		for category, count := range r.parser.warnings {
+			p.warnings[category] += count
+		}
+		p.courses += r.parser.courses
+		if r.err != nil {
+			if err := p.ctx.Err(); err != nil {
+				return nil, errors.Wrap(err, "stopped parsing")
+			}
+			// p continues to describe the input, e.g. when logging the
+			// failure.
+			p.line, p.faculty, p.course = r.parser.line, r.parser.faculty, r.parser.course
+			return nil, r.err
+		}
+		catalog = append(catalog, *r.catalog...)
+	}
+	return &catalog, nil
+}
+
+// chunkProgress sums up the progress of parsers of chunks of the input, which
+// run in parallel, reporting it as each of them parses a course.
+type chunkProgress struct {
+	mu      sync.Mutex
+	report  func(Progress)
+	lines   []uint
+	courses []int
+}
+
+func newChunkProgress(report func(Progress), chunks int) *chunkProgress {
+	if report == nil {
+		return nil
+	}
+	return &chunkProgress{report: report, lines: make([]uint, chunks), courses: make([]int, chunks)}
+}
+
+// chunk returns the progress function for the parser of the i'th chunk, which
+// starts after offset lines of the input.
+func (cp *chunkProgress) chunk(i int, offset uint) func(Progress) {
+	if cp == nil {
+		return nil
+	}
+	return func(chunk Progress) {
+		cp.mu.Lock()
+		defer cp.mu.Unlock()
+		cp.lines[i] = chunk.Lines - offset
+		cp.courses[i] = chunk.Courses
+		total := Progress{Bytes: chunk.Bytes}
+		for j := range cp.lines {
+			total.Lines += cp.lines[j]
+			total.Courses += cp.courses[j]
+		}
+		// Reporting under the lock means that calls aren't concurrent, and that
+		// totals never decrease.
+		cp.report(total)
+	}
+}
+
+// chunkParser returns a parser for lines, which start after the given amount
+// of lines of the input.
+func (p *parser) chunkParser(lines []string, offset uint, logger Logger) *parser {
+	return &parser{
+		ctx:      p.ctx,
+		course:   &Course{},
+		input:    p.input,
+		lines:    lines,
+		line:     offset,
+		logger:   logger,
+		warnings: map[WarningCategory]int{},
+	}
+}
+
+// bufferedLogger keeps log messages, so that those of faculties parsed in
+// parallel can be logged in order.
+type bufferedLogger struct {
+	records []logRecord
+}
+
+type logRecord struct {
+	ctx   context.Context
+	level slog.Level
+	msg   string
+	attrs []slog.Attr
+}
+
+func (b *bufferedLogger) Log(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	b.records = append(b.records, logRecord{ctx, level, msg, attrs})
+}
+
+func (b *bufferedLogger) Flush() {}
+
+func (b *bufferedLogger) replay(logger Logger) {
+	if b == nil {
+		return
+	}
+	for _, r := range b.records {
+		logger.Log(r.ctx, r.level, r.msg, r.attrs...)
+	}
+}
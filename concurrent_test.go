@@ -0,0 +1,146 @@
+package repy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSplitFaculties(t *testing.T) {
+	header := []string{facultySep, "name", "semester", facultySep}
+	sportsHeader := []string{sportsFacultySep, "semester", sportsFacultySep}
+
+	testCases := []struct {
+		name  string
+		lines [][]string
+		want  []int
+	}{
+		{"Empty", nil, []int{0}},
+		{"OneFaculty", [][]string{header, {"course"}}, []int{0}},
+		{"Preamble", [][]string{{"", ""}, header, {"course"}}, []int{0, 2}},
+		{"Several", [][]string{header, {"course", ""}, sportsHeader, {"course", ""}, header}, []int{0, 6, 11}},
+		{"NoBlankLine", [][]string{header, {"course"}, header}, []int{0}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var lines []string
+			for _, l := range tc.lines {
+				lines = append(lines, l...)
+			}
+			if d := cmp.Diff(tc.want, splitFaculties(lines)); d != "" {
+				t.Errorf("splitFaculties diff -want +got:\n%s", d)
+			}
+		})
+	}
+}
+
+// textLogger writes messages to a buffer, one per line.
+type textLogger struct {
+	buf bytes.Buffer
+}
+
+func (l *textLogger) Log(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	fmt.Fprintf(&l.buf, "%s %s\n", level, FormatMessage(msg, attrs))
+}
+
+func (l *textLogger) Flush() {}
+
+// readBothWays parses data sequentially and concurrently, checking that logs
+// and stats are identical, and returns both results.
+func readBothWays(t *testing.T, data []byte) (seq, conc *Catalog, seqErr, concErr error) {
+	t.Helper()
+	var seqLog, concLog textLogger
+	var seqStats, concStats Stats
+
+	seq, seqErr = ReadFileContext(context.Background(), bytes.NewReader(data), ReadOptions{
+		Logger: &seqLog, Stats: &seqStats,
+	})
+	conc, concErr = ReadFileContext(context.Background(), bytes.NewReader(data), ReadOptions{
+		Logger: &concLog, Stats: &concStats, Concurrency: 4,
+	})
+
+	if d := cmp.Diff(seqLog.buf.String(), concLog.buf.String()); d != "" {
+		t.Errorf("Log diff -sequential +concurrent:\n%s", d)
+	}
+	seqStats.Duration, concStats.Duration = 0, 0
+	if d := cmp.Diff(seqStats, concStats); d != "" {
+		t.Errorf("Stats diff -sequential +concurrent:\n%s", d)
+	}
+	return seq, conc, seqErr, concErr
+}
+
+func TestParseConcurrent(t *testing.T) {
+	for _, fullPathRepy := range getAllREPYs() {
+		t.Run(filepath.Base(fullPathRepy), func(t *testing.T) {
+			data := readTestREPY(t, fullPathRepy)
+			_, got, _, err := readBothWays(t, data)
+			if err != nil {
+				t.Fatalf("Error parsing concurrently: %v", err)
+			}
+
+			jsonGot, err := json.MarshalIndent(got, "", "  ")
+			if err != nil {
+				t.Fatalf("Failed to marshal JSON: %v", err)
+			}
+			fullPathJSON := strings.TrimSuffix(fullPathRepy, ".repy") + ".json"
+			jsonWant, err := ioutil.ReadFile(fullPathJSON)
+			if err != nil {
+				t.Fatalf("Couldn't open %q: %v", fullPathJSON, err)
+			}
+			if !bytes.Equal(jsonGot, jsonWant) {
+				t.Fatal("Concurrently parsed JSON differs from expected.")
+			}
+		})
+	}
+}
+
+func TestParseConcurrentErrors(t *testing.T) {
+	data := readTestREPY(t, bigREPY)
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	starts := splitFaculties(lines)
+	if len(starts) < 4 {
+		t.Fatalf("Only found %d faculties in %q", len(starts), bigREPY)
+	}
+
+	testCases := []struct {
+		name    string
+		corrupt func(lines []string)
+		// wantLine is the 1-based line number of the error.
+		wantLine int
+	}{
+		{"FacultyName", func(lines []string) { lines[starts[3]+1] = "garbage" }, starts[3] + 2},
+		{"FirstLine", func(lines []string) { lines[0] = "garbage" }, 1},
+		// The error is at the last line, as the input ends with a newline.
+		{"Truncated", func(lines []string) {
+			for i := starts[3] + 10; i < len(lines); i++ {
+				lines[i] = ""
+			}
+		}, len(lines) - 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			corrupted := append([]string(nil), lines...)
+			tc.corrupt(corrupted)
+			_, _, seqErr, concErr := readBothWays(t, []byte(strings.Join(corrupted, "\n")))
+			if seqErr == nil || concErr == nil {
+				t.Fatalf("Got errors %v, %v; want both to fail", seqErr, concErr)
+			}
+			if seqErr.Error() != concErr.Error() {
+				t.Errorf("Got error %q concurrently; want %q", concErr, seqErr)
+			}
+			if want := fmt.Sprintf("Line %d:", tc.wantLine); !strings.Contains(concErr.Error(), want) {
+				t.Errorf("Got error %q concurrently; want it to contain %q", concErr, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package repy
+
+import (
+	"encoding"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// CourseID is a Technion course number, in its canonical zero-padded form:
+// either an old-style 6-digit number such as "014003", or a new-style 8-digit
+// number such as "00140003". The empty CourseID is not a valid course number.
+type CourseID string
+
+// Lengths of canonical course IDs.
+const (
+	oldCourseIDLen = 6
+	newCourseIDLen = 8
+)
+
+// ParseCourseID parses a course number in either format. Leading zeros may be
+// omitted; numbers of up to 6 digits are old-style, and numbers of 7 or 8
+// digits are new-style.
+func ParseCourseID(s string) (CourseID, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "", errors.New("empty course ID")
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return "", errors.Errorf("invalid course ID %q", s)
+		}
+	}
+	switch {
+	case len(s) <= oldCourseIDLen:
+		return CourseID(strings.Repeat("0", oldCourseIDLen-len(s)) + s), nil
+	case len(s) <= newCourseIDLen:
+		return CourseID(strings.Repeat("0", newCourseIDLen-len(s)) + s), nil
+	}
+	return "", errors.Errorf("course ID %q is longer than %d digits", s, newCourseIDLen)
+}
+
+// IsNew returns whether id is in the 8-digit format.
+func (id CourseID) IsNew() bool {
+	return len(id) == newCourseIDLen
+}
+
+// prefix returns the digits identifying the faculty (or study program) of id,
+// which are the first two digits of old-style IDs and their equivalents in
+// new-style IDs.
+func (id CourseID) prefix() string {
+	if id.IsNew() {
+		return string(id[1:3])
+	}
+	if len(id) < 2 {
+		return string(id)
+	}
+	return string(id[:2])
+}
+
+// StandardNewID converts an old-style ID to the new format according to the
+// standard scheme, in which ABCDEF becomes 0ABC0DEF. IDs which are already
+// new-style are returned as they are.
+func StandardNewID(id CourseID) CourseID {
+	if len(id) != oldCourseIDLen {
+		return id
+	}
+	return "0" + id[:3] + "0" + id[3:]
+}
+
+// StandardOldID converts a new-style ID to the old format according to the
+// standard scheme, if it follows it. Old-style IDs are returned as they are.
+func StandardOldID(id CourseID) (CourseID, bool) {
+	if len(id) != newCourseIDLen {
+		return id, len(id) == oldCourseIDLen
+	}
+	if id[0] != '0' || id[4] != '0' {
+		return "", false
+	}
+	return id[1:4] + id[5:], true
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (id CourseID) MarshalText() ([]byte, error) {
+	return []byte(id), nil
+}
+
+var dummyCourseIDForStaticTypeChecks = CourseID("")
+var _ encoding.TextMarshaler = dummyCourseIDForStaticTypeChecks
+var _ encoding.TextUnmarshaler = &dummyCourseIDForStaticTypeChecks
+var _ json.Unmarshaler = &dummyCourseIDForStaticTypeChecks
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (id *CourseID) UnmarshalText(b []byte) error {
+	parsed, err := ParseCourseID(string(b))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Course IDs used to be exported as
+// numbers, which are accepted as well as strings.
+func (id *CourseID) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		var n uint64
+		if err := json.Unmarshal(b, &n); err != nil {
+			return errors.Errorf("invalid course ID %s", b)
+		}
+		s = strconv.FormatUint(n, 10)
+	}
+	return id.UnmarshalText([]byte(s))
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler, so that course IDs may be written
+// with or without leading zeros and quotes.
+func (id *CourseID) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	return id.UnmarshalText([]byte(s))
+}
+
+// IDMapping translates between old-style and new-style course IDs. IDs which
+// aren't listed in it are translated according to the standard scheme (see
+// StandardNewID). A nil *IDMapping only uses the standard scheme.
+type IDMapping struct {
+	toNew map[CourseID]CourseID
+	toOld map[CourseID]CourseID
+}
+
+// NewIDMapping returns a mapping from the given pairs of old-style IDs to
+// new-style IDs. Each ID may appear in at most one pair.
+func NewIDMapping(oldToNew map[CourseID]CourseID) (*IDMapping, error) {
+	m := &IDMapping{
+		toNew: map[CourseID]CourseID{},
+		toOld: map[CourseID]CourseID{},
+	}
+	for oldID, newID := range oldToNew {
+		if err := m.add(oldID, newID); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// ReadIDMapping reads a mapping from CSV with two columns: the old ID and the
+// new ID. A first line which doesn't start with a digit is taken to be a
+// header and skipped.
+func ReadIDMapping(r io.Reader) (*IDMapping, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read ID mapping")
+	}
+
+	m := &IDMapping{
+		toNew: map[CourseID]CourseID{},
+		toOld: map[CourseID]CourseID{},
+	}
+	for i, record := range records {
+		if i == 0 && (record[0] == "" || record[0][0] < '0' || record[0][0] > '9') {
+			continue
+		}
+		oldID, err := ParseCourseID(record[0])
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", i+1)
+		}
+		newID, err := ParseCourseID(record[1])
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", i+1)
+		}
+		if err := m.add(oldID, newID); err != nil {
+			return nil, errors.Wrapf(err, "line %d", i+1)
+		}
+	}
+	return m, nil
+}
+
+func (m *IDMapping) add(oldID, newID CourseID) error {
+	if oldID.IsNew() || !newID.IsNew() {
+		return errors.Errorf("invalid mapping %s -> %s: want an old-style ID and a new-style one", oldID, newID)
+	}
+	if prev, ok := m.toNew[oldID]; ok && prev != newID {
+		return errors.Errorf("%s is mapped to both %s and %s", oldID, prev, newID)
+	}
+	if prev, ok := m.toOld[newID]; ok && prev != oldID {
+		return errors.Errorf("%s is mapped from both %s and %s", newID, prev, oldID)
+	}
+	m.toNew[oldID] = newID
+	m.toOld[newID] = oldID
+	return nil
+}
+
+// New returns the new-style ID of id, which may already be new-style.
+func (m *IDMapping) New(id CourseID) CourseID {
+	if m != nil {
+		if newID, ok := m.toNew[id]; ok {
+			return newID
+		}
+	}
+	return StandardNewID(id)
+}
+
+// Old returns the old-style ID of id, which may already be old-style. It
+// returns false for new-style IDs which have no old-style equivalent.
+func (m *IDMapping) Old(id CourseID) (CourseID, bool) {
+	if m != nil {
+		if oldID, ok := m.toOld[id]; ok {
+			return oldID, true
+		}
+		if _, ok := m.toNew[id]; ok {
+			return id, true
+		}
+	}
+	oldID, ok := StandardOldID(id)
+	if ok && m != nil {
+		// The standard equivalent may have been remapped to another ID.
+		if newID, mapped := m.toNew[oldID]; mapped && newID != id {
+			return "", false
+		}
+	}
+	return oldID, ok
+}
+
+// ToNew returns a copy of c in which all course IDs are new-style, so that
+// catalogs from before the move to 8-digit IDs can be compared (e.g. with
+// DiffCatalogs) or merged with current ones.
+func (m *IDMapping) ToNew(c *Catalog) *Catalog {
+	result := make(Catalog, len(*c))
+	for i, f := range *c {
+		courses := make([]Course, len(f.Courses))
+		for j, course := range f.Courses {
+			course.ID = m.New(course.ID)
+			courses[j] = course
+		}
+		f.Courses = courses
+		result[i] = f
+	}
+	return &result
+}
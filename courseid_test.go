@@ -0,0 +1,169 @@
+package repy
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseCourseID(t *testing.T) {
+	testCases := []struct {
+		input   string
+		want    CourseID
+		wantErr bool
+	}{
+		{"234114", "234114", false},
+		{"14003", "014003", false},
+		{" 014003 ", "014003", false},
+		{"2340114", "02340114", false},
+		{"02340114", "02340114", false},
+		{"", "", true},
+		{"12a456", "", true},
+		{"123456789", "", true},
+	}
+
+	for _, tc := range testCases {
+		got, err := ParseCourseID(tc.input)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("ParseCourseID(%q) returned error %v; want error: %t", tc.input, err, tc.wantErr)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("ParseCourseID(%q) = %q; want %q", tc.input, got, tc.want)
+		}
+	}
+}
+
+func TestCourseIDJSON(t *testing.T) {
+	var got []CourseID
+	if err := json.Unmarshal([]byte(`[14003, "014003", "2340114", 234114]`), &got); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+	want := []CourseID{"014003", "014003", "02340114", "234114"}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Unmarshaled IDs diff -want +got:\n%s", d)
+	}
+
+	for _, bad := range []string{`-1`, `1.5`, `"abc"`, `true`} {
+		var id CourseID
+		if err := json.Unmarshal([]byte(bad), &id); err == nil {
+			t.Errorf("json.Unmarshal(%s) = %q; want error", bad, id)
+		}
+	}
+
+	b, err := json.Marshal(Course{ID: "014003"})
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+	if !bytes.Contains(b, []byte(`"id":"014003"`)) {
+		t.Errorf("Marshaled course %s doesn't have a zero-padded string ID", b)
+	}
+}
+
+func TestParseNewCourseID(t *testing.T) {
+	data := readTestREPY(t, "testdata/course_biology_1.repy")
+	// The name is right-aligned, so it moves left to make room for the ID.
+	data = bytes.Replace(data, []byte("|                       1"), []byte("|                      1"), 1)
+	data = bytes.Replace(data, []byte("  134058 |"), []byte(" 01340058 |"), 1)
+
+	c, err := ReadFile(bytes.NewReader(data), nil)
+	if err != nil {
+		t.Fatalf("ReadFile returned error: %v", err)
+	}
+	if got := (*c)[0].Courses[0].ID; got != "01340058" {
+		t.Errorf("Parsed course ID %q; want %q", got, "01340058")
+	}
+}
+
+func TestIDMapping(t *testing.T) {
+	m, err := ReadIDMapping(strings.NewReader("old,new\n234114,02340114\n104031, 01040195\n"))
+	if err != nil {
+		t.Fatalf("ReadIDMapping returned error: %v", err)
+	}
+
+	newTestCases := []struct {
+		input, want CourseID
+	}{
+		{"234114", "02340114"},
+		{"104031", "01040195"},
+		{"014003", "00140003"},
+		{"01040195", "01040195"},
+	}
+	for _, tc := range newTestCases {
+		if got := m.New(tc.input); got != tc.want {
+			t.Errorf("New(%q) = %q; want %q", tc.input, got, tc.want)
+		}
+	}
+
+	oldTestCases := []struct {
+		input  CourseID
+		want   CourseID
+		wantOK bool
+	}{
+		{"02340114", "234114", true},
+		{"01040195", "104031", true},
+		{"00140003", "014003", true},
+		{"014003", "014003", true},
+		{"01040031", "", false}, // 104031 was mapped elsewhere
+		{"12345678", "", false},
+	}
+	for _, tc := range oldTestCases {
+		got, ok := m.Old(tc.input)
+		if got != tc.want || ok != tc.wantOK {
+			t.Errorf("Old(%q) = %q, %t; want %q, %t", tc.input, got, ok, tc.want, tc.wantOK)
+		}
+	}
+
+	var standard *IDMapping
+	if got := standard.New("234114"); got != "02340114" {
+		t.Errorf("Standard New(%q) = %q; want %q", "234114", got, "02340114")
+	}
+}
+
+func TestReadIDMappingErrors(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+	}{
+		{"Swapped", "02340114,234114\n"},
+		{"BothOld", "234114,234115\n"},
+		{"Conflicting", "234114,02340114\n234114,02340115\n"},
+		{"SharedNewID", "234114,02340114\n234115,02340114\n"},
+		{"InvalidID", "234114,0234011x\n"},
+		{"TooManyColumns", "234114,02340114,x\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := ReadIDMapping(strings.NewReader(tc.input)); err == nil {
+				t.Errorf("ReadIDMapping(%q) succeeded; want error", tc.input)
+			}
+		})
+	}
+}
+
+func TestIDMappingToNew(t *testing.T) {
+	oldCatalog := catalogWithIDs(t, "234114", "014003")
+	newCatalog := catalogWithIDs(t, "02340114", "00140003")
+
+	var m *IDMapping
+	converted := m.ToNew(oldCatalog)
+	if d := DiffCatalogs(converted, newCatalog); len(d) != 0 {
+		t.Errorf("Converted catalog differs from new one: %v", d)
+	}
+	if (*oldCatalog)[0].Courses[0].ID != "234114" {
+		t.Errorf("ToNew modified its input")
+	}
+}
+
+func catalogWithIDs(t *testing.T, ids ...CourseID) *Catalog {
+	t.Helper()
+	c := Catalog{{Name: "faculty"}}
+	for _, id := range ids {
+		c[0].Courses = append(c[0].Courses, Course{ID: id, Name: "course"})
+	}
+	return &c
+}
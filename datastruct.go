@@ -21,6 +21,10 @@ type Faculty struct {
 	// Semester is specified per-faculty in the REPY file. Presumably this is for
 	// cases when not all faculties are up-to-date.
 	Semester string `json:"semester"`
+
+	// Source is the name of the archive member this faculty was parsed from,
+	// when parsing several members with Archive.ParseAll.
+	Source string `json:"source,omitempty"`
 }
 
 // WeeklyHours represents the amount of weekly hours, by type, that a course
@@ -34,7 +38,7 @@ type WeeklyHours struct {
 
 // Course represents information about a technion course.
 type Course struct {
-	ID               uint        `json:"id"`
+	ID               CourseID    `json:"id"`
 	Name             string      `json:"name"`
 	AcademicPoints   float32     `json:"academicPoints"`
 	LecturerInCharge string      `json:"lecturerInCharge"`
@@ -110,6 +114,9 @@ type Group struct {
 	Events      []Event   `json:"events"`
 	Type        GroupType `json:"type"`
 	Description string    `json:"description"`
+
+	// Sports is the structured form of Description, for Sport groups.
+	Sports *SportsInfo `json:"sports,omitempty"`
 }
 
 // Event represents a singular weekly event within a course.
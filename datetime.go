@@ -0,0 +1,191 @@
+package repy
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ParseDate parses a date in either YYYY-MM-DD or DD/MM/YYYY format.
+func ParseDate(s string) (Date, error) {
+	for _, layout := range []string{"2006-01-02", "2/1/2006"} {
+		if t, err := time.Parse(layout, strings.TrimSpace(s)); err == nil {
+			return DateOf(t), nil
+		}
+	}
+	return Date{}, errors.Errorf("invalid date %q", s)
+}
+
+// DateOf returns the date of t, in t's location.
+func DateOf(t time.Time) Date {
+	return Date{Year: uint(t.Year()), Month: uint(t.Month()), Day: uint(t.Day())}
+}
+
+// Time returns the midnight starting d in loc.
+func (d Date) Time(loc *time.Location) time.Time {
+	return time.Date(int(d.Year), time.Month(d.Month), int(d.Day), 0, 0, 0, 0, loc)
+}
+
+// String returns d in YYYY-MM-DD format.
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// Valid returns whether d is an actual date, e.g. not February 30th.
+func (d Date) Valid() bool {
+	return d.Month >= 1 && d.Month <= 12 && d.Day >= 1 && DateOf(d.Time(time.UTC)) == d
+}
+
+// Compare returns -1, 0 or 1 if d is before, the same as, or after other.
+func (d Date) Compare(other Date) int {
+	a := [3]uint{d.Year, d.Month, d.Day}
+	b := [3]uint{other.Year, other.Month, other.Day}
+	for i := range a {
+		if a[i] < b[i] {
+			return -1
+		}
+		if a[i] > b[i] {
+			return 1
+		}
+	}
+	return 0
+}
+
+// Before returns whether d is before other.
+func (d Date) Before(other Date) bool {
+	return d.Compare(other) < 0
+}
+
+// After returns whether d is after other.
+func (d Date) After(other Date) bool {
+	return d.Compare(other) > 0
+}
+
+// Weekday returns the day of the week of d.
+func (d Date) Weekday() time.Weekday {
+	return d.Time(time.UTC).Weekday()
+}
+
+// AddDays returns the date n days after d, or before it if n is negative.
+func (d Date) AddDays(n int) Date {
+	return DateOf(d.Time(time.UTC).AddDate(0, 0, n))
+}
+
+// DaysUntil returns the amount of days from d to other, which is negative if
+// other is before d.
+func (d Date) DaysUntil(other Date) int {
+	// UTC has no daylight saving time, so days are always 24 hours long.
+	return int(other.Time(time.UTC).Sub(d.Time(time.UTC)).Hours() / 24)
+}
+
+// EndOfDay is the latest valid MinutesSinceMidnight, i.e. "24:00".
+const EndOfDay = MinutesSinceMidnight(24 * 60)
+
+// ParseMinutesSinceMidnight parses a time of day in HH:MM format, up to
+// "24:00".
+func ParseMinutesSinceMidnight(s string) (MinutesSinceMidnight, error) {
+	parts := strings.Split(strings.TrimSpace(s), ":")
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[0]) > 2 || len(parts[1]) != 2 {
+		return 0, errors.Errorf("invalid time of day %q; want HH:MM", s)
+	}
+	h, errH := strconv.ParseUint(parts[0], 10, 8)
+	m, errM := strconv.ParseUint(parts[1], 10, 8)
+	if errH != nil || errM != nil || m >= 60 {
+		return 0, errors.Errorf("invalid time of day %q; want HH:MM", s)
+	}
+	t := MinutesSinceMidnight(h*60 + m)
+	if !t.Valid() {
+		return 0, errors.Errorf("invalid time of day %q; must be no later than %s", s, EndOfDay)
+	}
+	return t, nil
+}
+
+// Valid returns whether t is within a day, i.e. no later than EndOfDay.
+func (t MinutesSinceMidnight) Valid() bool {
+	return t <= EndOfDay
+}
+
+// Hour returns the hour of t.
+func (t MinutesSinceMidnight) Hour() int {
+	return int(t / 60)
+}
+
+// Minute returns the minute within the hour of t.
+func (t MinutesSinceMidnight) Minute() int {
+	return int(t % 60)
+}
+
+var _ encoding.TextMarshaler = MinutesSinceMidnight(0)
+var _ encoding.TextUnmarshaler = new(MinutesSinceMidnight)
+var _ json.Marshaler = MinutesSinceMidnight(0)
+var _ json.Unmarshaler = new(MinutesSinceMidnight)
+
+// MarshalText implements encoding.TextMarshaler, in HH:MM format.
+func (t MinutesSinceMidnight) MarshalText() ([]byte, error) {
+	if !t.Valid() {
+		return nil, errors.Errorf("invalid time of day of %d minutes", uint(t))
+	}
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, in HH:MM format.
+func (t *MinutesSinceMidnight) UnmarshalText(b []byte) error {
+	result, err := ParseMinutesSinceMidnight(string(b))
+	if err != nil {
+		return err
+	}
+	*t = result
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, as a number of minutes. Use ClockTime
+// to marshal as an "HH:MM" string instead.
+func (t MinutesSinceMidnight) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatUint(uint64(t), 10)), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting either a number of
+// minutes or a string in HH:MM format.
+func (t *MinutesSinceMidnight) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		return t.UnmarshalText([]byte(s))
+	}
+	var n uint
+	if err := json.Unmarshal(b, &n); err != nil {
+		return errors.Errorf("invalid time of day %s; want minutes or \"HH:MM\"", b)
+	}
+	*t = MinutesSinceMidnight(n)
+	return nil
+}
+
+// ClockTime is a MinutesSinceMidnight which marshals to JSON as an "HH:MM"
+// string, rather than as a number of minutes. Both forms are accepted when
+// unmarshaling.
+type ClockTime MinutesSinceMidnight
+
+var _ json.Marshaler = ClockTime(0)
+var _ json.Unmarshaler = new(ClockTime)
+
+func (t ClockTime) String() string {
+	return MinutesSinceMidnight(t).String()
+}
+
+// MarshalJSON implements json.Marshaler, in HH:MM format.
+func (t ClockTime) MarshalJSON() ([]byte, error) {
+	text, err := MinutesSinceMidnight(t).MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler, as for MinutesSinceMidnight.
+func (t *ClockTime) UnmarshalJSON(b []byte) error {
+	return (*MinutesSinceMidnight)(t).UnmarshalJSON(b)
+}
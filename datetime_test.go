@@ -0,0 +1,192 @@
+package repy
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseDate(t *testing.T) {
+	testCases := []struct {
+		in      string
+		want    Date
+		wantErr bool
+	}{
+		{in: "2020-01-30", want: Date{2020, 1, 30}},
+		{in: "30/1/2020", want: Date{2020, 1, 30}},
+		{in: " 05/02/2021 ", want: Date{2021, 2, 5}},
+		{in: "2020-02-30", wantErr: true},
+		{in: "yesterday", wantErr: true},
+		{in: "", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		got, err := ParseDate(tc.in)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("ParseDate(%q) returned error %v; wantErr %t", tc.in, err, tc.wantErr)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("ParseDate(%q) = %v; want %v", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestDate(t *testing.T) {
+	d := Date{2020, 2, 28}
+
+	if got, want := d.String(), "2020-02-28"; got != want {
+		t.Errorf("String() = %q; want %q", got, want)
+	}
+	if got, want := d.Weekday(), time.Friday; got != want {
+		t.Errorf("Weekday() = %s; want %s", got, want)
+	}
+	if got, want := d.AddDays(1), (Date{2020, 2, 29}); got != want {
+		t.Errorf("AddDays(1) = %s; want %s", got, want)
+	}
+	if got, want := d.AddDays(-59), (Date{2019, 12, 31}); got != want {
+		t.Errorf("AddDays(-59) = %s; want %s", got, want)
+	}
+	if got, want := d.DaysUntil(Date{2020, 3, 1}), 2; got != want {
+		t.Errorf("DaysUntil(2020-03-01) = %d; want %d", got, want)
+	}
+	if got, want := d.DaysUntil(Date{2019, 2, 28}), -365; got != want {
+		t.Errorf("DaysUntil(2019-02-28) = %d; want %d", got, want)
+	}
+
+	loc := time.FixedZone("IST", 2*60*60)
+	if got, want := d.Time(loc), time.Date(2020, 2, 28, 0, 0, 0, 0, loc); !got.Equal(want) {
+		t.Errorf("Time(IST) = %s; want %s", got, want)
+	}
+	if got := DateOf(d.Time(loc)); got != d {
+		t.Errorf("DateOf(Time(IST)) = %s; want %s", got, d)
+	}
+}
+
+func TestDateValid(t *testing.T) {
+	testCases := []struct {
+		d    Date
+		want bool
+	}{
+		{Date{2020, 2, 29}, true},
+		{Date{2021, 2, 29}, false},
+		{Date{2021, 4, 31}, false},
+		{Date{2021, 13, 1}, false},
+		{Date{2021, 1, 0}, false},
+		{Date{}, false},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.d.Valid(); got != tc.want {
+			t.Errorf("%v.Valid() = %t; want %t", tc.d, got, tc.want)
+		}
+	}
+}
+
+func TestDateCompare(t *testing.T) {
+	testCases := []struct {
+		a, b Date
+		want int
+	}{
+		{Date{2020, 1, 1}, Date{2020, 1, 1}, 0},
+		{Date{2020, 1, 2}, Date{2020, 1, 1}, 1},
+		{Date{2020, 1, 31}, Date{2020, 2, 1}, -1},
+		{Date{2019, 12, 31}, Date{2020, 1, 1}, -1},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.a.Compare(tc.b); got != tc.want {
+			t.Errorf("%s.Compare(%s) = %d; want %d", tc.a, tc.b, got, tc.want)
+		}
+		if got, want := tc.a.Before(tc.b), tc.want < 0; got != want {
+			t.Errorf("%s.Before(%s) = %t; want %t", tc.a, tc.b, got, want)
+		}
+		if got, want := tc.a.After(tc.b), tc.want > 0; got != want {
+			t.Errorf("%s.After(%s) = %t; want %t", tc.a, tc.b, got, want)
+		}
+	}
+}
+
+func TestParseMinutesSinceMidnight(t *testing.T) {
+	testCases := []struct {
+		in      string
+		want    MinutesSinceMidnight
+		wantErr bool
+	}{
+		{in: "00:00", want: 0},
+		{in: "8:30", want: 510},
+		{in: "13:05", want: 785},
+		{in: "24:00", want: EndOfDay},
+		{in: "24:01", wantErr: true},
+		{in: "12:60", wantErr: true},
+		{in: "12:5", wantErr: true},
+		{in: "123:00", wantErr: true},
+		{in: "-1:00", wantErr: true},
+		{in: "noon", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		got, err := ParseMinutesSinceMidnight(tc.in)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("ParseMinutesSinceMidnight(%q) returned error %v; wantErr %t", tc.in, err, tc.wantErr)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("ParseMinutesSinceMidnight(%q) = %d; want %d", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestMinutesSinceMidnightJSON(t *testing.T) {
+	type item struct {
+		Start MinutesSinceMidnight `json:"start"`
+	}
+	type clockItem struct {
+		Start ClockTime `json:"start"`
+	}
+
+	testCases := []struct {
+		name string
+		in   interface{}
+		want string
+	}{
+		{name: "MinutesSinceMidnight", in: item{Start: 510}, want: `{"start":510}`},
+		{name: "ClockTime", in: clockItem{Start: 510}, want: `{"start":"08:30"}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := json.Marshal(tc.in)
+			if err != nil {
+				t.Fatalf("Failed to marshal: %v", err)
+			}
+			if string(b) != tc.want {
+				t.Errorf("Marshal = %s; want %s", b, tc.want)
+			}
+
+			// Both forms are accepted by both types.
+			for _, s := range []string{`{"start":510}`, `{"start":"08:30"}`} {
+				got := reflect.New(reflect.TypeOf(tc.in))
+				if err := json.Unmarshal([]byte(s), got.Interface()); err != nil {
+					t.Errorf("Failed to unmarshal %s: %v", s, err)
+				} else if d := cmp.Diff(tc.in, got.Elem().Interface()); d != "" {
+					t.Errorf("Unmarshal %s diff -want +got:\n%s", s, d)
+				}
+			}
+		})
+	}
+
+	for _, s := range []string{`{"start":"25:00"}`, `{"start":true}`, `{"start":-5}`} {
+		var got item
+		if err := json.Unmarshal([]byte(s), &got); err == nil {
+			t.Errorf("Unmarshal %s = %+v; want error", s, got)
+		}
+	}
+
+	if b, err := json.Marshal(clockItem{Start: ClockTime(EndOfDay + 1)}); err == nil {
+		t.Errorf("Marshal of invalid ClockTime = %s; want error", b)
+	}
+}
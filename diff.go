@@ -0,0 +1,74 @@
+package repy
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+)
+
+// CourseChange describes a course which differs between two catalogs.
+type CourseChange struct {
+	ID CourseID `json:"id"`
+
+	// Old is nil for added courses.
+	Old *Course `json:"old,omitempty"`
+
+	// New is nil for removed courses.
+	New *Course `json:"new,omitempty"`
+}
+
+// DiffCatalogs returns the courses which were added, removed or changed from
+// oldCatalog to newCatalog, sorted by ID. Courses are compared by their JSON
+// representation, so nil and empty slices are considered equal only if they
+// marshal the same way. If a course appears in several faculties, only its
+// first appearance is compared.
+func DiffCatalogs(oldCatalog, newCatalog *Catalog) []CourseChange {
+	oldCourses := oldCatalog.coursesByID()
+	newCourses := newCatalog.coursesByID()
+
+	var result []CourseChange
+
+	for id, oldCourse := range oldCourses {
+		newCourse := newCourses[id]
+		if newCourse == nil || !sameJSON(oldCourse, newCourse) {
+			result = append(result, CourseChange{ID: id, Old: oldCourse, New: newCourse})
+		}
+	}
+	for id, newCourse := range newCourses {
+		if oldCourses[id] == nil {
+			result = append(result, CourseChange{ID: id, New: newCourse})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+// Group returns the group with the given ID in c, or nil if there isn't one.
+func (c *Course) Group(id uint) *Group {
+	if c == nil {
+		return nil
+	}
+	for i := range c.Groups {
+		if c.Groups[i].ID == id {
+			return &c.Groups[i]
+		}
+	}
+	return nil
+}
+
+// GroupChanged returns true if the group with the given ID was added, removed
+// or changed.
+func (cc CourseChange) GroupChanged(id uint) bool {
+	oldGroup, newGroup := cc.Old.Group(id), cc.New.Group(id)
+	if oldGroup == nil || newGroup == nil {
+		return oldGroup != newGroup
+	}
+	return !sameJSON(oldGroup, newGroup)
+}
+
+func sameJSON(a, b interface{}) bool {
+	aJSON, aErr := json.Marshal(a)
+	bJSON, bErr := json.Marshal(b)
+	return aErr == nil && bErr == nil && bytes.Equal(aJSON, bJSON)
+}
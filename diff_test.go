@@ -0,0 +1,50 @@
+package repy
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiffCatalogs(t *testing.T) {
+	group := func(id uint, day int) Group {
+		return Group{ID: id, Teachers: []string{}, Events: []Event{{Day: 0, StartMinute: MinutesSinceMidnight(day)}}}
+	}
+
+	oldCatalog := Catalog{{Courses: []Course{
+		{ID: "000001", Name: "unchanged", Groups: []Group{group(10, 1)}},
+		{ID: "000002", Name: "group changed", Groups: []Group{group(10, 1), group(11, 1)}},
+		{ID: "000003", Name: "removed"},
+	}}}
+	newCatalog := Catalog{{Courses: []Course{
+		{ID: "000004", Name: "added"},
+		{ID: "000002", Name: "group changed", Groups: []Group{group(10, 1), group(11, 2), group(12, 1)}},
+		{ID: "000001", Name: "unchanged", Groups: []Group{group(10, 1)}},
+	}}}
+
+	got := DiffCatalogs(&oldCatalog, &newCatalog)
+
+	var gotIDs []CourseID
+	for _, c := range got {
+		gotIDs = append(gotIDs, c.ID)
+	}
+	if d := cmp.Diff([]CourseID{"000002", "000003", "000004"}, gotIDs); d != "" {
+		t.Fatalf("DiffCatalogs() IDs diff -want +got:\n%s", d)
+	}
+
+	if got[1].New != nil || got[1].Old.Name != "removed" {
+		t.Errorf("Removed course change is %+v", got[1])
+	}
+	if got[2].Old != nil || got[2].New.Name != "added" {
+		t.Errorf("Added course change is %+v", got[2])
+	}
+
+	groupsChanged := map[uint]bool{}
+	for _, id := range []uint{10, 11, 12, 13} {
+		groupsChanged[id] = got[0].GroupChanged(id)
+	}
+	want := map[uint]bool{10: false, 11: true, 12: true, 13: false}
+	if d := cmp.Diff(want, groupsChanged); d != "" {
+		t.Errorf("GroupChanged() diff -want +got:\n%s", d)
+	}
+}
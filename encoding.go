@@ -0,0 +1,182 @@
+package repy
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/transform"
+)
+
+// Charset is the character encoding of REPY input.
+type Charset int
+
+const (
+	// DetectCharset detects the charset from the beginning of the input.
+	DetectCharset Charset = iota
+
+	// CP862 is the encoding of the original REPY file.
+	CP862
+
+	// ISO8859_8 is the encoding of the .txt copies published alongside REPY
+	// files.
+	ISO8859_8
+
+	// UTF8 is the encoding of REPY files converted by text editors.
+	UTF8
+)
+
+var charsetName = []string{
+	DetectCharset: "detect",
+	CP862:         "cp862",
+	ISO8859_8:     "iso8859-8",
+	UTF8:          "utf-8",
+}
+
+func (cs Charset) String() string {
+	if !cs.valid() {
+		return fmt.Sprintf("Charset(%d)", int(cs))
+	}
+	return charsetName[cs]
+}
+
+func (cs Charset) valid() bool {
+	return cs >= 0 && int(cs) < len(charsetName)
+}
+
+// Order is the ordering of Hebrew text in REPY input.
+type Order int
+
+const (
+	// DetectOrder detects the ordering from the beginning of the input.
+	DetectOrder Order = iota
+
+	// VisualOrder is the ordering of the original REPY file, in which each line
+	// is stored as it is displayed, left-to-right.
+	VisualOrder
+
+	// LogicalOrder is the ordering in which each line is stored in reading
+	// order, as produced by converting a REPY file with bidi.Reorder.
+	LogicalOrder
+)
+
+var orderName = []string{
+	DetectOrder:  "detect",
+	VisualOrder:  "visual",
+	LogicalOrder: "logical",
+}
+
+func (o Order) String() string {
+	if !o.valid() {
+		return fmt.Sprintf("Order(%d)", int(o))
+	}
+	return orderName[o]
+}
+
+func (o Order) valid() bool {
+	return o >= 0 && int(o) < len(orderName)
+}
+
+// detectionSampleSize is the amount of bytes used for detecting the Charset
+// and Order of REPY input. It easily covers the first faculty header.
+const detectionSampleSize = 8192
+
+// The word "semester" appears in every faculty header.
+var (
+	visualMarker  = []byte("רטסמס")
+	logicalMarker = []byte("סמסטר")
+)
+
+// decodeInput returns a UTF-8 reader for r, along with the Order of its
+// contents. Unless specified, the Charset and Order are detected from the
+// beginning of r.
+func decodeInput(r io.Reader, cs Charset, order Order) (io.Reader, Charset, Order) {
+	br := bufio.NewReaderSize(r, detectionSampleSize)
+	// Errors are deferred to subsequent reads.
+	sample, _ := br.Peek(detectionSampleSize)
+
+	if cs == DetectCharset {
+		cs = detectCharset(sample)
+	}
+
+	var decoded io.Reader = br
+	switch cs {
+	case CP862:
+		decoded = transform.NewReader(br, charmap.CodePage862.NewDecoder())
+	case ISO8859_8:
+		decoded = transform.NewReader(br, charmap.ISO8859_8.NewDecoder())
+	}
+
+	if order == DetectOrder {
+		order = detectOrder(decodeSample(sample, cs))
+	}
+
+	return decoded, cs, order
+}
+
+func detectCharset(sample []byte) Charset {
+	if isUTF8(sample) {
+		return UTF8
+	}
+
+	// Hebrew letters are 0x80-0x9a in CP862 and 0xe0-0xfa in ISO8859-8; REPY
+	// files use ASCII for everything else.
+	var cp862, iso8859_8 int
+	for _, b := range sample {
+		switch {
+		case 0x80 <= b && b <= 0x9a:
+			cp862++
+		case 0xe0 <= b && b <= 0xfa:
+			iso8859_8++
+		}
+	}
+
+	if iso8859_8 > cp862 {
+		return ISO8859_8
+	}
+	return CP862
+}
+
+// isUTF8 returns true if sample is valid, non-ASCII UTF-8, allowing for the
+// last rune to be cut off.
+func isUTF8(sample []byte) bool {
+	for i := 0; i < utf8.UTFMax && len(sample) > 0; i++ {
+		if utf8.Valid(sample) {
+			return !isASCII(sample)
+		}
+		sample = sample[:len(sample)-1]
+	}
+	return false
+}
+
+func isASCII(b []byte) bool {
+	for _, c := range b {
+		if c >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+func decodeSample(sample []byte, cs Charset) []byte {
+	var result []byte
+	switch cs {
+	case CP862:
+		result, _ = charmap.CodePage862.NewDecoder().Bytes(sample)
+	case ISO8859_8:
+		result, _ = charmap.ISO8859_8.NewDecoder().Bytes(sample)
+	default:
+		result = sample
+	}
+	return result
+}
+
+func detectOrder(decodedSample []byte) Order {
+	if !bytes.Contains(decodedSample, visualMarker) && bytes.Contains(decodedSample, logicalMarker) {
+		return LogicalOrder
+	}
+	return VisualOrder
+}
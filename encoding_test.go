@@ -0,0 +1,127 @@
+package repy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lutzky/repy/recode"
+	"golang.org/x/text/encoding/charmap"
+)
+
+func TestReadFileVariants(t *testing.T) {
+	for _, fullPathRepy := range getAllREPYs() {
+		t.Run(filepath.Base(fullPathRepy), func(t *testing.T) {
+			original := readTestREPY(t, fullPathRepy)
+
+			jsonWant, err := ioutil.ReadFile(strings.TrimSuffix(fullPathRepy, ".repy") + ".json")
+			if err != nil {
+				t.Fatalf("Couldn't read golden JSON: %v", err)
+			}
+
+			iso8859_8, err := recode.Recode(charmap.CodePage862, charmap.ISO8859_8, original)
+			if err != nil {
+				t.Fatalf("Failed to recode to ISO8859-8: %v", err)
+			}
+			utf8Data, err := charmap.CodePage862.NewDecoder().Bytes(original)
+			if err != nil {
+				t.Fatalf("Failed to decode to UTF-8: %v", err)
+			}
+
+			variants := []struct {
+				name    string
+				data    []byte
+				charset Charset
+				order   Order
+			}{
+				{"iso8859-8", iso8859_8, ISO8859_8, VisualOrder},
+				{"utf-8", utf8Data, UTF8, VisualOrder},
+			}
+
+			for _, v := range variants {
+				t.Run(v.name, func(t *testing.T) {
+					sample := v.data
+					if len(sample) > detectionSampleSize {
+						sample = sample[:detectionSampleSize]
+					}
+					if got := detectCharset(sample); got != v.charset {
+						t.Errorf("detectCharset() = %s; want %s", got, v.charset)
+					}
+					if got := detectOrder(decodeSample(sample, v.charset)); got != v.order {
+						t.Errorf("detectOrder() = %s; want %s", got, v.order)
+					}
+
+					got, err := ReadFileContext(context.Background(), bytes.NewReader(v.data), ReadOptions{})
+					if err != nil {
+						t.Fatalf("Error parsing: %v", err)
+					}
+					jsonGot, err := json.MarshalIndent(got, "", "  ")
+					if err != nil {
+						t.Fatalf("Failed to marshal JSON: %v", err)
+					}
+					if !bytes.Equal(jsonGot, jsonWant) {
+						t.Errorf("Parsed JSON differs from %s original", CP862)
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestDetectCharsetOriginal(t *testing.T) {
+	for _, fullPathRepy := range getAllREPYs() {
+		data := readTestREPY(t, fullPathRepy)
+		if got := detectCharset(data); got != CP862 {
+			t.Errorf("detectCharset(%q) = %s; want %s", fullPathRepy, got, CP862)
+		}
+	}
+}
+
+func TestReadFileLogicalOrder(t *testing.T) {
+	// Written by hand, in the order in which a text editor stores lines which
+	// are displayed right-to-left.
+	data := readTestREPY(t, "testdata/course_storage_systems_logical.utf8")
+	jsonWant, err := ioutil.ReadFile("testdata/course_storage_systems.json")
+	if err != nil {
+		t.Fatalf("Couldn't read golden JSON: %v", err)
+	}
+
+	if got := detectCharset(data); got != UTF8 {
+		t.Errorf("detectCharset() = %s; want %s", got, UTF8)
+	}
+	if got := detectOrder(data); got != LogicalOrder {
+		t.Errorf("detectOrder() = %s; want %s", got, LogicalOrder)
+	}
+
+	got, err := ReadFileContext(context.Background(), bytes.NewReader(data), ReadOptions{})
+	if err != nil {
+		t.Fatalf("Error parsing: %v", err)
+	}
+	jsonGot, err := json.MarshalIndent(got, "", "  ")
+	if err != nil {
+		t.Fatalf("Failed to marshal JSON: %v", err)
+	}
+	if !bytes.Equal(jsonGot, jsonWant) {
+		t.Errorf("Parsed JSON differs from golden:\n%s", jsonGot)
+	}
+}
+
+func TestUnknownEncoding(t *testing.T) {
+	if got, want := Charset(5).String(), "Charset(5)"; got != want {
+		t.Errorf("Charset(5).String() = %q; want %q", got, want)
+	}
+	if got, want := Order(-1).String(), "Order(-1)"; got != want {
+		t.Errorf("Order(-1).String() = %q; want %q", got, want)
+	}
+
+	data := readTestREPY(t, "testdata/course_statistics.repy")
+	for _, opts := range []ReadOptions{{Charset: 5}, {Order: 3}} {
+		if _, err := ReadFileContext(context.Background(), bytes.NewReader(data), opts); err == nil {
+			t.Errorf("ReadFileContext with %+v succeeded; want error", opts)
+		}
+	}
+}
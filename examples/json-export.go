@@ -2,19 +2,21 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"flag"
 	"io"
-	"net/http"
 	"os"
 
 	"github.com/golang/glog"
 	"github.com/lutzky/repy"
+	"github.com/lutzky/repy/fetch"
 )
 
 var (
 	inputFile       = flag.String("input_repy_file", "", "REPY file to read")
 	repfileURL      = flag.String("repfile_url", repy.RepFileURL, "URL to REPFILE.zip")
+	cacheDir        = flag.String("cache_dir", "", "If set, cache REPFILE.zip in this directory")
 	desiredREPYFile = flag.String("desired_repy_file", "REPY", "Desired name of REPY file inside REPFILE.zip")
 )
 
@@ -25,15 +27,21 @@ func main() {
 	var err error
 
 	if *inputFile == "" {
-		resp, err := http.Get(*repfileURL)
+		f := fetch.Fetcher{CacheDir: *cacheDir}
+		result, err := f.Fetch(context.Background(), *repfileURL)
 		if err != nil {
 			glog.Exitf("Error downloading REPY from %s: %v", *repfileURL, err)
 		}
 
-		if zipBytes, err := repy.ExtractFromZip(resp.Body); err != nil {
-			glog.Exitf("Failed to extract REPY from zip in %s: %v", *repfileURL, err)
+		archive, err := repy.ReadArchive(bytes.NewReader(result.Body), repy.DefaultArchiveLimits)
+		if err != nil {
+			glog.Exitf("Failed to read zip from %s: %v", *repfileURL, err)
+		}
+
+		if repyBytes, err := archive.Extract(*desiredREPYFile); err != nil {
+			glog.Exitf("Failed to extract %q from zip in %s: %v", *desiredREPYFile, *repfileURL, err)
 		} else {
-			repyReader = bytes.NewReader(zipBytes)
+			repyReader = bytes.NewReader(repyBytes)
 		}
 	} else {
 		f, err := os.Open(*inputFile)
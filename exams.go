@@ -0,0 +1,154 @@
+package repy
+
+import (
+	"sort"
+)
+
+// CourseSet is a named set of courses whose exams are taken together, such as
+// a single semester of a study track.
+type CourseSet struct {
+	Name    string     `json:"name" yaml:"name"`
+	Courses []CourseID `json:"courses" yaml:"courses"`
+}
+
+// Exam is a single test date of a course. Moed is the index of the date within
+// Course.TestDates, so 0 is Moed A and 1 is Moed B.
+type Exam struct {
+	CourseID   CourseID `json:"courseId"`
+	CourseName string   `json:"courseName"`
+	Moed       int      `json:"moed"`
+	Date       Date     `json:"date"`
+}
+
+// ExamConflict is a pair of exams, of different courses in the same moed,
+// which are too close to each other.
+type ExamConflict struct {
+	First     Exam `json:"first"`
+	Second    Exam `json:"second"`
+	DaysApart int  `json:"daysApart"`
+}
+
+// ExamSpread describes the exam period of a single moed within a CourseSet.
+type ExamSpread struct {
+	Moed  int  `json:"moed"`
+	First Date `json:"first"`
+	Last  Date `json:"last"`
+	Days  int  `json:"days"`
+}
+
+// ExamReport is the result of analyzing the exams of a single CourseSet.
+type ExamReport struct {
+	Set CourseSet `json:"set"`
+
+	// SameDay lists exams taking place on the same day.
+	SameDay []ExamConflict `json:"sameDay"`
+
+	// TooClose lists exams taking place on different days, but fewer than the
+	// requested minimum amount of days apart.
+	TooClose []ExamConflict `json:"tooClose"`
+
+	// Spreads has an entry for each moed in which any of the courses has an
+	// exam.
+	Spreads []ExamSpread `json:"spreads"`
+
+	// MissingCourses lists course IDs from Set which are not in the catalog.
+	MissingCourses []CourseID `json:"missingCourses,omitempty"`
+}
+
+// AnalyzeExams looks for exam clashes within each of sets. Exams are only
+// compared against exams in the same moed; exams fewer than minDaysApart days
+// apart are reported as TooClose.
+func AnalyzeExams(c *Catalog, sets []CourseSet, minDaysApart int) []ExamReport {
+	courses := c.coursesByID()
+
+	var reports []ExamReport
+	for _, set := range sets {
+		reports = append(reports, analyzeCourseSet(courses, set, minDaysApart))
+	}
+	return reports
+}
+
+// coursesByID returns a map of all courses in c. If a course appears in
+// several faculties, the first appearance is used.
+func (c *Catalog) coursesByID() map[CourseID]*Course {
+	result := map[CourseID]*Course{}
+	for i := range *c {
+		faculty := &(*c)[i]
+		for j := range faculty.Courses {
+			course := &faculty.Courses[j]
+			if _, ok := result[course.ID]; !ok {
+				result[course.ID] = course
+			}
+		}
+	}
+	return result
+}
+
+func analyzeCourseSet(courses map[CourseID]*Course, set CourseSet, minDaysApart int) ExamReport {
+	report := ExamReport{
+		Set:      set,
+		SameDay:  []ExamConflict{},
+		TooClose: []ExamConflict{},
+		Spreads:  []ExamSpread{},
+	}
+
+	var examsByMoed [][]Exam
+
+	for _, id := range set.Courses {
+		course, ok := courses[id]
+		if !ok {
+			report.MissingCourses = append(report.MissingCourses, id)
+			continue
+		}
+		for moed, d := range course.TestDates {
+			for len(examsByMoed) <= moed {
+				examsByMoed = append(examsByMoed, nil)
+			}
+			examsByMoed[moed] = append(examsByMoed[moed], Exam{
+				CourseID:   course.ID,
+				CourseName: course.Name,
+				Moed:       moed,
+				Date:       d,
+			})
+		}
+	}
+
+	for moed, exams := range examsByMoed {
+		if len(exams) == 0 {
+			continue
+		}
+
+		sort.SliceStable(exams, func(i, j int) bool {
+			return exams[i].Date.Before(exams[j].Date)
+		})
+
+		first, last := exams[0].Date, exams[len(exams)-1].Date
+		report.Spreads = append(report.Spreads, ExamSpread{
+			Moed:  moed,
+			First: first,
+			Last:  last,
+			Days:  first.DaysUntil(last),
+		})
+
+		for i := range exams {
+			for j := i + 1; j < len(exams); j++ {
+				if exams[i].CourseID == exams[j].CourseID {
+					continue
+				}
+				days := exams[i].Date.DaysUntil(exams[j].Date)
+				if days >= minDaysApart && days > 0 {
+					// Exams are sorted, so later ones are even further apart.
+					break
+				}
+				conflict := ExamConflict{exams[i], exams[j], days}
+				if days == 0 {
+					report.SameDay = append(report.SameDay, conflict)
+				} else {
+					report.TooClose = append(report.TooClose, conflict)
+				}
+			}
+		}
+	}
+
+	return report
+}
@@ -0,0 +1,68 @@
+package repy
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAnalyzeExams(t *testing.T) {
+	catalog := Catalog{
+		{
+			Name: "faculty1",
+			Courses: []Course{
+				{ID: "000001", Name: "one", TestDates: []Date{{2019, 1, 20}, {2019, 3, 1}}},
+				{ID: "000002", Name: "two", TestDates: []Date{{2019, 1, 20}, {2019, 3, 10}}},
+				{ID: "000003", Name: "three", TestDates: []Date{{2019, 1, 22}}},
+			},
+		},
+		{
+			Name: "faculty2",
+			Courses: []Course{
+				{ID: "000004", Name: "four", TestDates: []Date{{2019, 2, 5}}},
+				{ID: "000005", Name: "five"},
+			},
+		},
+	}
+
+	sets := []CourseSet{
+		{Name: "clashing", Courses: []CourseID{"000001", "000002", "000003"}},
+		{Name: "relaxed", Courses: []CourseID{"000003", "000004", "000005", "000006"}},
+	}
+
+	exam := func(id CourseID, name string, moed int, d Date) Exam {
+		return Exam{CourseID: id, CourseName: name, Moed: moed, Date: d}
+	}
+
+	want := []ExamReport{
+		{
+			Set: sets[0],
+			SameDay: []ExamConflict{
+				{exam("000001", "one", 0, Date{2019, 1, 20}), exam("000002", "two", 0, Date{2019, 1, 20}), 0},
+			},
+			TooClose: []ExamConflict{
+				{exam("000001", "one", 0, Date{2019, 1, 20}), exam("000003", "three", 0, Date{2019, 1, 22}), 2},
+				{exam("000002", "two", 0, Date{2019, 1, 20}), exam("000003", "three", 0, Date{2019, 1, 22}), 2},
+			},
+			Spreads: []ExamSpread{
+				{Moed: 0, First: Date{2019, 1, 20}, Last: Date{2019, 1, 22}, Days: 2},
+				{Moed: 1, First: Date{2019, 3, 1}, Last: Date{2019, 3, 10}, Days: 9},
+			},
+		},
+		{
+			Set:      sets[1],
+			SameDay:  []ExamConflict{},
+			TooClose: []ExamConflict{},
+			Spreads: []ExamSpread{
+				{Moed: 0, First: Date{2019, 1, 22}, Last: Date{2019, 2, 5}, Days: 14},
+			},
+			MissingCourses: []CourseID{"000006"},
+		},
+	}
+
+	got := AnalyzeExams(&catalog, sets, 3)
+
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("AnalyzeExams() diff -want +got:\n%s", d)
+	}
+}
@@ -0,0 +1,277 @@
+// Package fetch downloads files such as REPFILE.zip over HTTP, using
+// conditional requests, bounded retries and a local cache.
+package fetch
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Defaults for the corresponding Fetcher fields.
+const (
+	DefaultTimeout = 30 * time.Second
+	DefaultRetries = 3
+	DefaultBackoff = time.Second
+	DefaultMaxSize = 32 << 20
+)
+
+// ErrTooLarge is returned (wrapped) when a response exceeds Fetcher.MaxSize.
+var ErrTooLarge = errors.New("response too large")
+
+// StatusError is returned for unexpected HTTP responses.
+type StatusError struct {
+	URL        string
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("GET %s: unexpected status %d %s", e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// temporary returns true for statuses worth retrying.
+func (e *StatusError) temporary() bool {
+	return e.StatusCode >= 500 || e.StatusCode == http.StatusTooManyRequests
+}
+
+// Fetcher downloads URLs, remembering the ETag and Last-Modified headers of
+// previous responses so that unchanged files aren't downloaded again. The zero
+// value is usable, and caches in memory. It is safe for concurrent use.
+type Fetcher struct {
+	// Client is used for requests. If nil, http.DefaultClient is used.
+	Client *http.Client
+
+	// Timeout applies to each attempt. Zero means DefaultTimeout.
+	Timeout time.Duration
+
+	// Retries is the amount of additional attempts made after temporary
+	// failures. Zero means DefaultRetries; negative means no retries.
+	Retries int
+
+	// Backoff is the delay before the first retry, doubled for each
+	// subsequent retry. Zero means DefaultBackoff.
+	Backoff time.Duration
+
+	// MaxSize is the maximum size of a response body. Zero means
+	// DefaultMaxSize.
+	MaxSize int64
+
+	// CacheDir, if set, is a directory in which responses are cached across
+	// Fetchers. Otherwise, responses are cached in memory.
+	CacheDir string
+
+	mu     sync.Mutex
+	memory map[string]*entry
+}
+
+// Result is a successfully fetched file.
+type Result struct {
+	Body []byte
+
+	// NotModified is true if the server reported that the file hasn't changed
+	// since it was last fetched; Body is then taken from the cache.
+	NotModified bool
+
+	ETag         string
+	LastModified string
+}
+
+// entry is a cached response.
+type entry struct {
+	URL          string `json:"url"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	body         []byte
+}
+
+// Fetch downloads url, retrying temporary failures.
+func (f *Fetcher) Fetch(ctx context.Context, url string) (*Result, error) {
+	cached := f.load(url)
+
+	retries := f.Retries
+	if retries == 0 {
+		retries = DefaultRetries
+	}
+	backoff := f.Backoff
+	if backoff == 0 {
+		backoff = DefaultBackoff
+	}
+
+	for attempt := 0; ; attempt++ {
+		result, err := f.fetchOnce(ctx, url, cached)
+		if err == nil {
+			return result, nil
+		}
+		if se, ok := errors.Cause(err).(*StatusError); ok && !se.temporary() {
+			return nil, err
+		}
+		if errors.Is(err, ErrTooLarge) || attempt >= retries {
+			return nil, errors.Wrapf(err, "failed after %d attempts", attempt+1)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, errors.Wrapf(ctx.Err(), "gave up on %s after %d attempts (last error: %v)", url, attempt+1, err)
+		case <-time.After(backoff << uint(attempt)):
+		}
+	}
+}
+
+func (f *Fetcher) fetchOnce(ctx context.Context, url string, cached *entry) (*Result, error) {
+	timeout := f.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid request for %s", url)
+	}
+	req = req.WithContext(ctx)
+	if cached != nil {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to download %s", url)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached != nil:
+		return &Result{
+			Body:         cached.body,
+			NotModified:  true,
+			ETag:         cached.ETag,
+			LastModified: cached.LastModified,
+		}, nil
+	case resp.StatusCode != http.StatusOK:
+		return nil, &StatusError{URL: url, StatusCode: resp.StatusCode}
+	}
+
+	maxSize := f.MaxSize
+	if maxSize == 0 {
+		maxSize = DefaultMaxSize
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read response from %s", url)
+	}
+	if int64(len(body)) > maxSize {
+		return nil, errors.Wrapf(ErrTooLarge, "%s is larger than %d bytes", url, maxSize)
+	}
+
+	e := &entry{
+		URL:          url,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		body:         body,
+	}
+	if err := f.store(e); err != nil {
+		return nil, errors.Wrapf(err, "failed to cache %s", url)
+	}
+
+	return &Result{
+		Body:         body,
+		ETag:         e.ETag,
+		LastModified: e.LastModified,
+	}, nil
+}
+
+func (f *Fetcher) cachePaths(url string) (meta, body string) {
+	base := filepath.Join(f.CacheDir, fmt.Sprintf("%x", sha1.Sum([]byte(url))))
+	return base + ".json", base + ".body"
+}
+
+// load returns the cached entry for url, or nil if there isn't a usable one.
+func (f *Fetcher) load(url string) *entry {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.CacheDir == "" {
+		return f.memory[url]
+	}
+
+	metaPath, bodyPath := f.cachePaths(url)
+	metaBytes, err := ioutil.ReadFile(metaPath)
+	if err != nil {
+		return nil
+	}
+	var e entry
+	if err := json.Unmarshal(metaBytes, &e); err != nil || e.URL != url {
+		return nil
+	}
+	if e.body, err = ioutil.ReadFile(bodyPath); err != nil {
+		return nil
+	}
+	return &e
+}
+
+func (f *Fetcher) store(e *entry) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.CacheDir == "" {
+		if f.memory == nil {
+			f.memory = map[string]*entry{}
+		}
+		f.memory[e.URL] = e
+		return nil
+	}
+
+	if err := os.MkdirAll(f.CacheDir, 0755); err != nil {
+		return err
+	}
+	metaBytes, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	metaPath, bodyPath := f.cachePaths(e.URL)
+	// The body is written first, so that metadata never refers to a partial
+	// body.
+	if err := writeFileAtomic(bodyPath, e.body); err != nil {
+		return err
+	}
+	return writeFileAtomic(metaPath, metaBytes)
+}
+
+func writeFileAtomic(filename string, data []byte) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(filename), filepath.Base(filename)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(tmp, bytes.NewReader(data)); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), filename)
+}
@@ -0,0 +1,179 @@
+package fetch
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const testBody = "REPFILE contents"
+
+// newServer returns a server serving testBody with the given ETag and
+// Last-Modified headers (if non-empty), honoring conditional requests. The
+// number of requests and of full responses are counted.
+func newServer(t *testing.T, etag, lastModified string) (server *httptest.Server, requests, full *int32) {
+	requests, full = new(int32), new(int32)
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		if etag != "" {
+			w.Header().Set("ETag", etag)
+			if r.Header.Get("If-None-Match") == etag {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+		if lastModified != "" {
+			w.Header().Set("Last-Modified", lastModified)
+			if r.Header.Get("If-Modified-Since") == lastModified {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+		atomic.AddInt32(full, 1)
+		w.Write([]byte(testBody))
+	}))
+	t.Cleanup(server.Close)
+	return server, requests, full
+}
+
+func fetchTwice(t *testing.T, f1, f2 *Fetcher, url string) (first, second *Result) {
+	t.Helper()
+	var err error
+	if first, err = f1.Fetch(context.Background(), url); err != nil {
+		t.Fatalf("First Fetch failed: %v", err)
+	}
+	if second, err = f2.Fetch(context.Background(), url); err != nil {
+		t.Fatalf("Second Fetch failed: %v", err)
+	}
+	for _, r := range []*Result{first, second} {
+		if string(r.Body) != testBody {
+			t.Errorf("Fetch returned %q; want %q", r.Body, testBody)
+		}
+	}
+	return first, second
+}
+
+func TestConditional(t *testing.T) {
+	testCases := []struct {
+		name, etag, lastModified string
+	}{
+		{"ETag", `"abc"`, ""},
+		{"LastModified", "", "Mon, 02 Jan 2006 15:04:05 GMT"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server, _, full := newServer(t, tc.etag, tc.lastModified)
+			var f Fetcher
+			first, second := fetchTwice(t, &f, &f, server.URL)
+			if first.NotModified || !second.NotModified {
+				t.Errorf("NotModified = %v, %v; want false, true", first.NotModified, second.NotModified)
+			}
+			if *full != 1 {
+				t.Errorf("Server sent %d full responses; want 1", *full)
+			}
+		})
+	}
+}
+
+func TestNoValidators(t *testing.T) {
+	server, _, full := newServer(t, "", "")
+	var f Fetcher
+	_, second := fetchTwice(t, &f, &f, server.URL)
+	if second.NotModified {
+		t.Errorf("NotModified is set without validators")
+	}
+	if *full != 2 {
+		t.Errorf("Server sent %d full responses; want 2", *full)
+	}
+}
+
+func TestDiskCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fetch-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	server, _, full := newServer(t, `"abc"`, "")
+	_, second := fetchTwice(t, &Fetcher{CacheDir: dir}, &Fetcher{CacheDir: dir}, server.URL)
+	if !second.NotModified {
+		t.Errorf("Second fetcher didn't use the disk cache")
+	}
+	if *full != 1 {
+		t.Errorf("Server sent %d full responses; want 1", *full)
+	}
+}
+
+func TestRetries(t *testing.T) {
+	testCases := []struct {
+		name         string
+		statuses     []int
+		wantErr      bool
+		wantRequests int32
+	}{
+		{"Success", []int{200}, false, 1},
+		{"RecoversFrom500", []int{500, 503, 200}, false, 3},
+		{"RecoversFrom429", []int{429, 200}, false, 2},
+		{"GivesUp", []int{500, 500, 500, 500, 200}, true, 4},
+		{"NotFoundIsFinal", []int{404, 200}, true, 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := atomic.AddInt32(&requests, 1)
+				w.WriteHeader(tc.statuses[n-1])
+				w.Write([]byte(testBody))
+			}))
+			defer server.Close()
+
+			f := Fetcher{Backoff: time.Millisecond}
+			_, err := f.Fetch(context.Background(), server.URL)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("Fetch returned error %v; want error: %v", err, tc.wantErr)
+			}
+			if requests != tc.wantRequests {
+				t.Errorf("Server got %d requests; want %d", requests, tc.wantRequests)
+			}
+		})
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	f := Fetcher{Timeout: 10 * time.Millisecond, Retries: -1}
+	if _, err := f.Fetch(context.Background(), server.URL); err == nil {
+		t.Errorf("Fetch succeeded; want timeout")
+	}
+}
+
+func TestMaxSize(t *testing.T) {
+	server, requests, _ := newServer(t, "", "")
+
+	f := Fetcher{MaxSize: int64(len(testBody) - 1)}
+	_, err := f.Fetch(context.Background(), server.URL)
+	if !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Fetch returned %v; want %v", err, ErrTooLarge)
+	}
+	if *requests != 1 {
+		t.Errorf("Server got %d requests; want 1 (no retries)", *requests)
+	}
+}
@@ -0,0 +1,85 @@
+package repy
+
+import (
+	"strings"
+	"time"
+)
+
+// CourseFilter selects courses from a catalog. Zero-valued fields match all
+// courses.
+type CourseFilter struct {
+	ID CourseID
+
+	// Faculty is the exact name of the faculty.
+	Faculty string
+
+	// Lecturer is part of the name of the lecturer in charge, or of any
+	// teacher of the course.
+	Lecturer string
+
+	// Day, if not nil, is a day on which the course has events.
+	Day *time.Weekday
+}
+
+// Match returns whether c, which is in faculty f, matches the filter.
+func (cf CourseFilter) Match(f *Faculty, c *Course) bool {
+	if cf.Faculty != "" && f.Name != cf.Faculty {
+		return false
+	}
+	if cf.ID != "" && c.ID != cf.ID {
+		return false
+	}
+	if cf.Lecturer != "" && !strings.Contains(c.LecturerInCharge, cf.Lecturer) && !c.anyGroup(func(g Group) bool {
+		return g.HasTeacher(cf.Lecturer)
+	}) {
+		return false
+	}
+	if cf.Day != nil && !c.anyGroup(func(g Group) bool { return g.HasEventOn(*cf.Day) }) {
+		return false
+	}
+	return true
+}
+
+// Courses returns the courses in c which match cf, in order. Courses listed in
+// several faculties may appear more than once.
+func (c *Catalog) Courses(cf CourseFilter) []Course {
+	result := []Course{}
+	for i := range *c {
+		f := &(*c)[i]
+		for j := range f.Courses {
+			if cf.Match(f, &f.Courses[j]) {
+				result = append(result, f.Courses[j])
+			}
+		}
+	}
+	return result
+}
+
+func (c *Course) anyGroup(pred func(Group) bool) bool {
+	for _, g := range c.Groups {
+		if pred(g) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasTeacher returns whether name is part of the name of any of g's teachers.
+func (g Group) HasTeacher(name string) bool {
+	for _, t := range g.Teachers {
+		if strings.Contains(t, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasEventOn returns whether g has events on day.
+func (g Group) HasEventOn(day time.Weekday) bool {
+	for _, ev := range g.Events {
+		if ev.Day == day {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package repy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCatalogCourses(t *testing.T) {
+	group := func(teacher string, day time.Weekday) Group {
+		return Group{Teachers: []string{teacher}, Events: []Event{{Day: day}}}
+	}
+	catalog := Catalog{
+		{Name: "CS", Courses: []Course{
+			{ID: "234114", LecturerInCharge: "Alice", Groups: []Group{group("Bob", time.Sunday)}},
+			{ID: "234117", LecturerInCharge: "Carol", Groups: []Group{group("Dave", time.Monday)}},
+		}},
+		{Name: "Math", Courses: []Course{
+			{ID: "104031", LecturerInCharge: "Erin", Groups: []Group{group("Bob", time.Monday)}},
+			{ID: "234117", LecturerInCharge: "Carol"},
+		}},
+	}
+	monday := time.Monday
+
+	testCases := []struct {
+		name   string
+		filter CourseFilter
+		want   []CourseID
+	}{
+		{"All", CourseFilter{}, []CourseID{"234114", "234117", "104031", "234117"}},
+		{"ID", CourseFilter{ID: "234117"}, []CourseID{"234117", "234117"}},
+		{"Faculty", CourseFilter{Faculty: "Math"}, []CourseID{"104031", "234117"}},
+		{"LecturerInCharge", CourseFilter{Lecturer: "Car"}, []CourseID{"234117", "234117"}},
+		{"Teacher", CourseFilter{Lecturer: "Bob"}, []CourseID{"234114", "104031"}},
+		{"Day", CourseFilter{Day: &monday}, []CourseID{"234117", "104031"}},
+		{"Combined", CourseFilter{Faculty: "CS", Day: &monday}, []CourseID{"234117"}},
+		{"None", CourseFilter{Lecturer: "Mallory"}, []CourseID{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := []CourseID{}
+			for _, c := range catalog.Courses(tc.filter) {
+				got = append(got, c.ID)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Courses(%+v) diff -want +got:\n%s", tc.filter, d)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package repy
+
+import (
+	"time"
+)
+
+// FreeTimeOptions restrict the slots found by FreeTime.
+type FreeTimeOptions struct {
+	// Days to look for free time in. Defaults to Sunday through Thursday.
+	Days []time.Weekday
+
+	// From and To limit free time to a time of day. To defaults to
+	// EndOfDay.
+	From, To MinutesSinceMidnight
+
+	// MinLength is the minimal length of slots, in minutes.
+	MinLength MinutesSinceMidnight
+}
+
+// DefaultFreeTimeDays are the days FreeTime looks in by default, the
+// Technion's teaching days.
+var DefaultFreeTimeDays = []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday}
+
+// FreeTime returns the slots in which none of the groups in any of selections
+// take place, such as when all members of a study group are free, sorted by
+// day and time. It also returns the references in selections to groups which
+// aren't in c, which are otherwise ignored.
+func (c *Catalog) FreeTime(selections []Selection, opts FreeTimeOptions) (slots []WeeklyInterval, missing []GroupRef) {
+	if opts.Days == nil {
+		opts.Days = DefaultFreeTimeDays
+	}
+	if opts.To == 0 || opts.To > EndOfDay {
+		opts.To = EndOfDay
+	}
+
+	var window []WeeklyInterval
+	for _, day := range opts.Days {
+		window = append(window, WeeklyInterval{Day: day, Start: opts.From, End: opts.To})
+	}
+
+	var busy []WeeklyInterval
+	for _, s := range selections {
+		events, m := c.Resolve(s)
+		missing = append(missing, m...)
+		for _, ev := range events {
+			busy = append(busy, ev.Event.Interval())
+		}
+	}
+
+	for _, free := range Subtract(window, busy) {
+		if free.Length() >= opts.MinLength {
+			slots = append(slots, free)
+		}
+	}
+	return slots, missing
+}
@@ -0,0 +1,82 @@
+package repy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFreeTime(t *testing.T) {
+	event := func(day time.Weekday, start, end MinutesSinceMidnight) Event {
+		return Event{Day: day, StartMinute: start, EndMinute: end}
+	}
+	catalog := Catalog{{Courses: []Course{
+		{ID: "234114", Groups: []Group{
+			{ID: 10, Events: []Event{event(time.Sunday, 600, 720), event(time.Tuesday, 600, 660)}},
+			{ID: 11, Events: []Event{event(time.Monday, 600, 720)}},
+		}},
+		{ID: "104031", Groups: []Group{
+			{ID: 10, Events: []Event{event(time.Sunday, 690, 780)}},
+			{ID: 11, Events: []Event{event(time.Sunday, 840, 900)}},
+		}},
+	}}}
+
+	alice := Selection{{"234114", 10}, {"104031", 10}}
+	bob := Selection{{"104031", 11}, {"999999", 10}}
+
+	testCases := []struct {
+		name string
+		opts FreeTimeOptions
+		want []WeeklyInterval
+	}{
+		{
+			name: "whole day",
+			opts: FreeTimeOptions{Days: []time.Weekday{time.Sunday}},
+			want: []WeeklyInterval{
+				{time.Sunday, 0, 600},
+				{time.Sunday, 780, 840},
+				{time.Sunday, 900, 1440},
+			},
+		},
+		{
+			name: "time of day",
+			opts: FreeTimeOptions{Days: []time.Weekday{time.Tuesday, time.Sunday}, From: 510, To: 1080},
+			want: []WeeklyInterval{
+				{time.Sunday, 510, 600},
+				{time.Sunday, 780, 840},
+				{time.Sunday, 900, 1080},
+				{time.Tuesday, 510, 600},
+				{time.Tuesday, 660, 1080},
+			},
+		},
+		{
+			name: "min length",
+			opts: FreeTimeOptions{From: 510, To: 1080, MinLength: 120},
+			want: []WeeklyInterval{
+				{time.Sunday, 900, 1080},
+				{time.Monday, 510, 1080},
+				{time.Tuesday, 660, 1080},
+				{time.Wednesday, 510, 1080},
+				{time.Thursday, 510, 1080},
+			},
+		},
+		{
+			name: "busy all day",
+			opts: FreeTimeOptions{Days: []time.Weekday{time.Sunday}, From: 630, To: 750},
+			want: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, missing := catalog.FreeTime([]Selection{alice, bob}, tc.opts)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("FreeTime diff -want +got:\n%s", d)
+			}
+			if d := cmp.Diff([]GroupRef{{"999999", 10}}, missing); d != "" {
+				t.Errorf("Missing refs diff -want +got:\n%s", d)
+			}
+		})
+	}
+}
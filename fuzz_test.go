@@ -0,0 +1,111 @@
+package repy
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func addREPYSeeds(f *testing.F, transform func(data []byte) []byte) {
+	f.Helper()
+	for _, fullPathRepy := range getAllREPYs() {
+		data, err := ioutil.ReadFile(fullPathRepy)
+		if err != nil {
+			f.Fatalf("Couldn't read %q: %v", fullPathRepy, err)
+		}
+		f.Add(transform(data))
+	}
+}
+
+func FuzzReadFile(f *testing.F) {
+	addREPYSeeds(f, func(data []byte) []byte { return data })
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		c, err := ReadFile(bytes.NewReader(data), nil)
+		if err == nil && c == nil {
+			t.Errorf("ReadFile returned neither a catalog nor an error")
+		}
+	})
+}
+
+func FuzzExtractFromZip(f *testing.F) {
+	addREPYSeeds(f, func(data []byte) []byte {
+		var buf bytes.Buffer
+		w := zip.NewWriter(&buf)
+		zf, err := w.Create(REPYFileName)
+		if err != nil {
+			f.Fatalf("Failed to create zip member: %v", err)
+		}
+		zf.Write(data)
+		if err := w.Close(); err != nil {
+			f.Fatalf("Failed to close zip: %v", err)
+		}
+		return buf.Bytes()
+	})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		ExtractFromZip(bytes.NewReader(data))
+	})
+}
+
+func TestReadFileTruncated(t *testing.T) {
+	for _, fullPathRepy := range getAllREPYs() {
+		t.Run(filepath.Base(fullPathRepy), func(t *testing.T) {
+			data, err := ioutil.ReadFile(fullPathRepy)
+			if err != nil {
+				t.Fatalf("Couldn't read %q: %v", fullPathRepy, err)
+			}
+
+			step := len(data)/8 + 1
+			for n := 0; n < len(data); n += step {
+				// Must not panic or loop forever; errors are fine.
+				ReadFile(bytes.NewReader(data[:n]), nil)
+			}
+		})
+	}
+}
+
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) { return 0, r.err }
+
+func TestReadFileErrors(t *testing.T) {
+	statistics, err := ioutil.ReadFile("testdata/course_statistics.repy")
+	if err != nil {
+		t.Fatalf("Couldn't read testdata: %v", err)
+	}
+	lines := bytes.SplitAfter(statistics, []byte("\n"))
+
+	testCases := []struct {
+		name     string
+		input    []byte
+		wantLine string
+	}{
+		{"LineTooLong", bytes.Repeat([]byte{'|'}, 100000), "Line 1:"},
+		{"GarbageFacultySeparator", []byte("hello\n"), "Line 1:"},
+		{"TruncatedFacultyHeader", []byte(facultySep + "\n" +
+			"| 234 - תועש תכרעמ |\n"), "Line 2:"},
+		{"TruncatedGroups", bytes.Join(lines[:18], nil), "Line 18:"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ReadFile(bytes.NewReader(tc.input), nil)
+			if err == nil {
+				t.Fatalf("ReadFile(%.20q...) succeeded; want error", tc.input)
+			}
+			if !strings.Contains(err.Error(), tc.wantLine) {
+				t.Errorf("ReadFile(%.20q...) returned %q; want it to contain %q", tc.input, err, tc.wantLine)
+			}
+		})
+	}
+
+	t.Run("ReaderError", func(t *testing.T) {
+		if _, err := ReadFile(errReader{bytes.ErrTooLarge}, nil); err == nil {
+			t.Errorf("ReadFile of failing reader succeeded; want error")
+		}
+	})
+}
@@ -0,0 +1,120 @@
+// Package graphql serves the catalogs published with package publish over
+// GraphQL. The schema mirrors repy.Catalog, with arguments for filtering
+// courses, groups and events, so that clients can fetch exactly the fields
+// they need in a single request.
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/lutzky/repy"
+	"github.com/lutzky/repy/publish"
+	"github.com/pkg/errors"
+)
+
+// Handler answers GraphQL queries over the catalogs in a publish.Store. Each
+// query selects a version of the catalog with the "version" argument (the
+// SHA1 sum of the REPY file) or the "semester" argument (the latest version of
+// that semester); by default, the latest version is used.
+type Handler struct {
+	catalogs *publish.Reader
+	schema   gql.Schema
+}
+
+// NewHandler returns a Handler for the catalogs in store.
+func NewHandler(store publish.Store) (*Handler, error) {
+	h := &Handler{catalogs: publish.NewReader(store)}
+	schema, err := h.newSchema()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create GraphQL schema")
+	}
+	h.schema = schema
+	return h, nil
+}
+
+// Do executes a GraphQL query.
+func (h *Handler) Do(ctx context.Context, query string, variables map[string]interface{}, operationName string) *gql.Result {
+	return gql.Do(gql.Params{
+		Schema:         h.schema,
+		RequestString:  query,
+		VariableValues: variables,
+		OperationName:  operationName,
+		Context:        ctx,
+	})
+}
+
+// request is the body of a GraphQL request sent over HTTP.
+type request struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+}
+
+// ServeHTTP serves GraphQL queries, either POSTed as JSON or in the "query",
+// "variables" and "operationName" URL parameters of a GET request.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req request
+	switch r.Method {
+	case http.MethodGet:
+		q := r.URL.Query()
+		req.Query = q.Get("query")
+		req.OperationName = q.Get("operationName")
+		if v := q.Get("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+				http.Error(w, "Invalid variables: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if req.Query == "" {
+		http.Error(w, "Missing query", http.StatusBadRequest)
+		return
+	}
+
+	result := h.Do(r.Context(), req.Query, req.Variables, req.OperationName)
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// catalog returns the catalog selected by the "version" and "semester"
+// arguments.
+func (h *Handler) catalog(ctx context.Context, args map[string]interface{}) (*repy.Catalog, error) {
+	version, _ := args["version"].(string)
+	semester, _ := args["semester"].(string)
+	c, _, err := h.catalogs.Catalog(ctx, version, semester)
+	return c, err
+}
+
+// courseFilter returns the filter described by the "id", "faculty",
+// "lecturer" and "day" arguments.
+func courseFilter(args map[string]interface{}) (repy.CourseFilter, error) {
+	var f repy.CourseFilter
+	if s, ok := args["id"].(string); ok {
+		id, err := repy.ParseCourseID(s)
+		if err != nil {
+			return f, err
+		}
+		f.ID = id
+	}
+	f.Faculty, _ = args["faculty"].(string)
+	f.Lecturer, _ = args["lecturer"].(string)
+	if day, ok := args["day"].(time.Weekday); ok {
+		f.Day = &day
+	}
+	return f, nil
+}
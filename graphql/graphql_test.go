@@ -0,0 +1,198 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lutzky/repy/publish"
+)
+
+// newTestHandler publishes a winter catalog and then a spring catalog, and
+// returns a Handler for them along with their SHA1 sums.
+func newTestHandler(t *testing.T) (*Handler, []string) {
+	t.Helper()
+	ctx := context.Background()
+	store := publish.Dir(t.TempDir())
+
+	var sums []string
+	now := time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC)
+	for _, name := range []string{"course_biology_1.repy", "course_sport_sample.repy"} {
+		data, err := ioutil.ReadFile("../testdata/" + name)
+		if err != nil {
+			t.Fatalf("Couldn't read %q: %v", name, err)
+		}
+		published := now
+		result, err := publish.Publish(ctx, store, data, publish.Options{Now: func() time.Time { return published }})
+		if err != nil {
+			t.Fatalf("Publish(%q) failed: %v", name, err)
+		}
+		sums = append(sums, result.SHA1Sum)
+		now = now.Add(time.Hour)
+	}
+
+	h, err := NewHandler(store)
+	if err != nil {
+		t.Fatalf("NewHandler failed: %v", err)
+	}
+	return h, sums
+}
+
+// jsonValue unmarshals s, so that it can be compared with query results.
+func jsonValue(t *testing.T, s string) interface{} {
+	t.Helper()
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatalf("Invalid JSON %q: %v", s, err)
+	}
+	return v
+}
+
+func TestQueries(t *testing.T) {
+	h, sums := newTestHandler(t)
+
+	testCases := []struct {
+		name      string
+		query     string
+		variables map[string]interface{}
+		want      string
+	}{
+		{
+			name:  "Versions",
+			query: `{ versions { semester } }`,
+			want:  `{"versions": [{"semester": "אביב תשע\"ח"}, {"semester": "חורף תשע\"ו"}]}`,
+		},
+		{
+			name:  "Latest",
+			query: `{ courses { id name } }`,
+			want:  `{"courses": [{"id": "394902", "name": "נבחרות ספורט"}]}`,
+		},
+		{
+			name:  "BySemester",
+			query: `{ faculties(semester: "חורף תשע\"ו") { semester courses { id } } }`,
+			want:  `{"faculties": [{"semester": "חורף תשע\"ו", "courses": [{"id": "134058"}]}]}`,
+		},
+		{
+			name:      "ByVersion",
+			query:     `query($v: String) { courses(version: $v, lecturer: "מאירי") { id } }`,
+			variables: map[string]interface{}{"v": sums[0]},
+			want:      `{"courses": [{"id": "134058"}]}`,
+		},
+		{
+			name: "NestedFilters",
+			query: `query($v: String) {
+				course(version: $v, id: "134058") {
+					groups(type: LECTURE, day: THURSDAY) { id sports { activity } events(day: THURSDAY) { day start end } }
+				}
+			}`,
+			variables: map[string]interface{}{"v": sums[0]},
+			want: `{"course": {"groups": [
+				{"id": 10, "sports": null, "events": [{"day": "THURSDAY", "start": "12:30", "end": "13:30"}]}
+			]}}`,
+		},
+		{
+			name:  "Sports",
+			query: `{ course(id: "394902") { groups(day: THURSDAY) { id sports { activity gender unparsed } } } }`,
+			want: `{"course": {"groups": [
+				{"id": 13, "sports": {"activity": "כדורסל", "gender": "men", "unparsed": ["אסא"]}},
+				{"id": 22, "sports": {"activity": "סיוף", "gender": "mixed", "unparsed": []}},
+				{"id": 42, "sports": {"activity": "קטרגל", "gender": "women", "unparsed": []}}
+			]}}`,
+		},
+		{
+			name:  "NoMatch",
+			query: `{ course(id: "234114") { name } courses(day: SATURDAY) { id } }`,
+			want:  `{"course": null, "courses": []}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := h.Do(context.Background(), tc.query, tc.variables, "")
+			if result.HasErrors() {
+				t.Fatalf("Query returned errors: %v", result.Errors)
+			}
+			b, err := json.Marshal(result.Data)
+			if err != nil {
+				t.Fatalf("Failed to marshal result: %v", err)
+			}
+			if d := cmp.Diff(jsonValue(t, tc.want), jsonValue(t, string(b))); d != "" {
+				t.Errorf("Result diff -want +got:\n%s", d)
+			}
+		})
+	}
+}
+
+func TestQueryErrors(t *testing.T) {
+	h, _ := newTestHandler(t)
+
+	for _, query := range []string{
+		`{ courses(version: "0000000000000000000000000000000000000000") { id } }`,
+		`{ courses(semester: "קיץ תש\"ף") { id } }`,
+		`{ course(id: "not an ID") { id } }`,
+		`{ courses { nonexistent } }`,
+	} {
+		if result := h.Do(context.Background(), query, nil, ""); !result.HasErrors() {
+			t.Errorf("Query %q succeeded; want errors", query)
+		}
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	h, _ := newTestHandler(t)
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	const want = `{"data": {"courses": [{"id": "394902"}]}}`
+	query := `query($id: String) { courses(id: $id) { id } }`
+
+	check := func(name string, resp *http.Response, err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("%s failed: %v", name, err)
+		}
+		defer resp.Body.Close()
+		body, _ := ioutil.ReadAll(resp.Body)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("%s returned %s: %s", name, resp.Status, body)
+		}
+		if d := cmp.Diff(jsonValue(t, want), jsonValue(t, string(body))); d != "" {
+			t.Errorf("%s response diff -want +got:\n%s", name, d)
+		}
+	}
+
+	params := url.Values{"query": {query}, "variables": {`{"id": "394902"}`}}
+	resp, err := http.Get(server.URL + "?" + params.Encode())
+	check("GET", resp, err)
+
+	body := `{"query": "query($id: String) { courses(id: $id) { id } }", "variables": {"id": "394902"}}`
+	resp, err = http.Post(server.URL, "application/json", strings.NewReader(body))
+	check("POST", resp, err)
+
+	badRequests := []struct {
+		method, body string
+		want         int
+	}{
+		{http.MethodPost, `not JSON`, http.StatusBadRequest},
+		{http.MethodPost, `{}`, http.StatusBadRequest},
+		{http.MethodPut, ``, http.StatusMethodNotAllowed},
+	}
+	for _, br := range badRequests {
+		req, _ := http.NewRequest(br.method, server.URL, strings.NewReader(br.body))
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s failed: %v", br.method, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != br.want {
+			t.Errorf("%s %q returned %d; want %d", br.method, br.body, resp.StatusCode, br.want)
+		}
+	}
+}
@@ -0,0 +1,349 @@
+package graphql
+
+import (
+	"time"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/lutzky/repy"
+	"github.com/lutzky/repy/publish"
+)
+
+var dayEnum = gql.NewEnum(gql.EnumConfig{
+	Name: "Day",
+	Values: gql.EnumValueConfigMap{
+		"SUNDAY":    &gql.EnumValueConfig{Value: time.Sunday},
+		"MONDAY":    &gql.EnumValueConfig{Value: time.Monday},
+		"TUESDAY":   &gql.EnumValueConfig{Value: time.Tuesday},
+		"WEDNESDAY": &gql.EnumValueConfig{Value: time.Wednesday},
+		"THURSDAY":  &gql.EnumValueConfig{Value: time.Thursday},
+		"FRIDAY":    &gql.EnumValueConfig{Value: time.Friday},
+		"SATURDAY":  &gql.EnumValueConfig{Value: time.Saturday},
+	},
+})
+
+var groupTypeEnum = gql.NewEnum(gql.EnumConfig{
+	Name: "GroupType",
+	Values: gql.EnumValueConfigMap{
+		"LECTURE":  &gql.EnumValueConfig{Value: repy.Lecture},
+		"TUTORIAL": &gql.EnumValueConfig{Value: repy.Tutorial},
+		"LAB":      &gql.EnumValueConfig{Value: repy.Lab},
+		"SPORT":    &gql.EnumValueConfig{Value: repy.Sport},
+	},
+})
+
+// minutes resolves a repy.MinutesSinceMidnight field of an Event.
+func minutes(get func(repy.Event) repy.MinutesSinceMidnight, asString bool) gql.FieldResolveFn {
+	return func(p gql.ResolveParams) (interface{}, error) {
+		m := get(p.Source.(repy.Event))
+		if asString {
+			return m.String(), nil
+		}
+		return int(m), nil
+	}
+}
+
+func startMinute(ev repy.Event) repy.MinutesSinceMidnight { return ev.StartMinute }
+func endMinute(ev repy.Event) repy.MinutesSinceMidnight   { return ev.EndMinute }
+
+var eventType = gql.NewObject(gql.ObjectConfig{
+	Name: "Event",
+	Fields: gql.Fields{
+		"day":         &gql.Field{Type: gql.NewNonNull(dayEnum)},
+		"location":    &gql.Field{Type: gql.NewNonNull(gql.String)},
+		"startMinute": &gql.Field{Type: gql.NewNonNull(gql.Int), Resolve: minutes(startMinute, false)},
+		"endMinute":   &gql.Field{Type: gql.NewNonNull(gql.Int), Resolve: minutes(endMinute, false)},
+		"start": &gql.Field{
+			Type:        gql.NewNonNull(gql.String),
+			Description: "Start time, as HH:MM",
+			Resolve:     minutes(startMinute, true),
+		},
+		"end": &gql.Field{
+			Type:        gql.NewNonNull(gql.String),
+			Description: "End time, as HH:MM",
+			Resolve:     minutes(endMinute, true),
+		},
+	},
+})
+
+// optionalString resolves string fields which are empty when unknown to null.
+func optionalString(get func(repy.SportsInfo) string) gql.FieldResolveFn {
+	return func(p gql.ResolveParams) (interface{}, error) {
+		if s := get(*p.Source.(*repy.SportsInfo)); s != "" {
+			return s, nil
+		}
+		return nil, nil
+	}
+}
+
+var sportsInfoType = gql.NewObject(gql.ObjectConfig{
+	Name: "SportsInfo",
+	Fields: gql.Fields{
+		"activity": &gql.Field{Type: gql.NewNonNull(gql.String)},
+		"gender": &gql.Field{
+			Type:    gql.String,
+			Resolve: optionalString(func(si repy.SportsInfo) string { return string(si.Gender) }),
+		},
+		"level": &gql.Field{
+			Type:    gql.String,
+			Resolve: optionalString(func(si repy.SportsInfo) string { return string(si.Level) }),
+		},
+		"medicineOnly": &gql.Field{Type: gql.NewNonNull(gql.Boolean)},
+		"unparsed": &gql.Field{
+			Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(gql.String))),
+			Resolve: func(p gql.ResolveParams) (interface{}, error) {
+				if unparsed := p.Source.(*repy.SportsInfo).Unparsed; unparsed != nil {
+					return unparsed, nil
+				}
+				return []string{}, nil
+			},
+		},
+	},
+})
+
+var groupType = gql.NewObject(gql.ObjectConfig{
+	Name: "Group",
+	Fields: gql.Fields{
+		"id":          &gql.Field{Type: gql.NewNonNull(gql.Int)},
+		"type":        &gql.Field{Type: gql.NewNonNull(groupTypeEnum)},
+		"description": &gql.Field{Type: gql.NewNonNull(gql.String)},
+		"teachers":    &gql.Field{Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(gql.String)))},
+		"sports":      &gql.Field{Type: sportsInfoType},
+		"events": &gql.Field{
+			Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(eventType))),
+			Args: gql.FieldConfigArgument{
+				"day": &gql.ArgumentConfig{Type: dayEnum},
+			},
+			Resolve: func(p gql.ResolveParams) (interface{}, error) {
+				events := p.Source.(repy.Group).Events
+				day, ok := p.Args["day"].(time.Weekday)
+				result := []repy.Event{}
+				for _, ev := range events {
+					if !ok || ev.Day == day {
+						result = append(result, ev)
+					}
+				}
+				return result, nil
+			},
+		},
+	},
+})
+
+var weeklyHoursType = gql.NewObject(gql.ObjectConfig{
+	Name: "WeeklyHours",
+	Fields: gql.Fields{
+		"lecture":  &gql.Field{Type: gql.NewNonNull(gql.Int)},
+		"tutorial": &gql.Field{Type: gql.NewNonNull(gql.Int)},
+		"lab":      &gql.Field{Type: gql.NewNonNull(gql.Int)},
+		"project":  &gql.Field{Type: gql.NewNonNull(gql.Int)},
+	},
+})
+
+var dateType = gql.NewObject(gql.ObjectConfig{
+	Name: "Date",
+	Fields: gql.Fields{
+		"year":  &gql.Field{Type: gql.NewNonNull(gql.Int)},
+		"month": &gql.Field{Type: gql.NewNonNull(gql.Int)},
+		"day":   &gql.Field{Type: gql.NewNonNull(gql.Int)},
+	},
+})
+
+var courseType = gql.NewObject(gql.ObjectConfig{
+	Name: "Course",
+	Fields: gql.Fields{
+		"id":               &gql.Field{Type: gql.NewNonNull(gql.String)},
+		"name":             &gql.Field{Type: gql.NewNonNull(gql.String)},
+		"academicPoints":   &gql.Field{Type: gql.NewNonNull(gql.Float)},
+		"lecturerInCharge": &gql.Field{Type: gql.NewNonNull(gql.String)},
+		"weeklyHours":      &gql.Field{Type: gql.NewNonNull(weeklyHoursType)},
+		"testDates":        &gql.Field{Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(dateType)))},
+		"notes":            &gql.Field{Type: gql.NewNonNull(gql.String)},
+		"groups": &gql.Field{
+			Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(groupType))),
+			Args: gql.FieldConfigArgument{
+				"type":    &gql.ArgumentConfig{Type: groupTypeEnum},
+				"day":     &gql.ArgumentConfig{Type: dayEnum},
+				"teacher": &gql.ArgumentConfig{Type: gql.String},
+			},
+			Resolve: func(p gql.ResolveParams) (interface{}, error) {
+				groups := p.Source.(repy.Course).Groups
+				groupType, filterType := p.Args["type"].(repy.GroupType)
+				day, filterDay := p.Args["day"].(time.Weekday)
+				teacher, _ := p.Args["teacher"].(string)
+				result := []repy.Group{}
+				for _, g := range groups {
+					if (!filterType || g.Type == groupType) &&
+						(!filterDay || g.HasEventOn(day)) &&
+						(teacher == "" || g.HasTeacher(teacher)) {
+						result = append(result, g)
+					}
+				}
+				return result, nil
+			},
+		},
+	},
+})
+
+// courseArgs are the arguments for filtering courses.
+var courseArgs = gql.FieldConfigArgument{
+	"id": &gql.ArgumentConfig{
+		Type:        gql.String,
+		Description: "Course ID, in either the 6-digit or the 8-digit format",
+	},
+	"lecturer": &gql.ArgumentConfig{
+		Type:        gql.String,
+		Description: "Part of the name of the lecturer in charge or of any teacher",
+	},
+	"day": &gql.ArgumentConfig{
+		Type:        dayEnum,
+		Description: "A day on which the course has events",
+	},
+}
+
+var facultyType = gql.NewObject(gql.ObjectConfig{
+	Name: "Faculty",
+	Fields: gql.Fields{
+		"name":     &gql.Field{Type: gql.NewNonNull(gql.String)},
+		"semester": &gql.Field{Type: gql.NewNonNull(gql.String)},
+		"courses": &gql.Field{
+			Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(courseType))),
+			Args: courseArgs,
+			Resolve: func(p gql.ResolveParams) (interface{}, error) {
+				filter, err := courseFilter(p.Args)
+				if err != nil {
+					return nil, err
+				}
+				f := p.Source.(repy.Faculty)
+				return (&repy.Catalog{f}).Courses(filter), nil
+			},
+		},
+	},
+})
+
+var versionType = gql.NewObject(gql.ObjectConfig{
+	Name: "Version",
+	Fields: gql.Fields{
+		"sha1Sum": &gql.Field{
+			Type: gql.NewNonNull(gql.String),
+			Resolve: func(p gql.ResolveParams) (interface{}, error) {
+				return p.Source.(publish.IndexEntry).Sha1Sum, nil
+			},
+		},
+		"timeStamp": &gql.Field{
+			Type:        gql.NewNonNull(gql.DateTime),
+			Description: "The time at which this version was first published",
+			Resolve: func(p gql.ResolveParams) (interface{}, error) {
+				return p.Source.(publish.IndexEntry).TimeStamp, nil
+			},
+		},
+		"semester": &gql.Field{
+			Type: gql.NewNonNull(gql.String),
+			Resolve: func(p gql.ResolveParams) (interface{}, error) {
+				return p.Source.(publish.IndexEntry).Semester, nil
+			},
+		},
+	},
+})
+
+// versionArgs are the arguments for selecting a version of the catalog.
+var versionArgs = gql.FieldConfigArgument{
+	"version": &gql.ArgumentConfig{
+		Type:        gql.String,
+		Description: "SHA1 sum of the REPY file; the latest by default",
+	},
+	"semester": &gql.ArgumentConfig{
+		Type:        gql.String,
+		Description: "Use the latest version of this semester",
+	},
+}
+
+// withArgs returns the union of several sets of arguments.
+func withArgs(sets ...gql.FieldConfigArgument) gql.FieldConfigArgument {
+	result := gql.FieldConfigArgument{}
+	for _, args := range sets {
+		for name, arg := range args {
+			result[name] = arg
+		}
+	}
+	return result
+}
+
+func (h *Handler) newSchema() (gql.Schema, error) {
+	query := gql.NewObject(gql.ObjectConfig{
+		Name: "Query",
+		Fields: gql.Fields{
+			"versions": &gql.Field{
+				Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(versionType))),
+				Args: gql.FieldConfigArgument{
+					"semester": &gql.ArgumentConfig{Type: gql.String},
+				},
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					semester, _ := p.Args["semester"].(string)
+					versions, err := h.catalogs.Versions(p.Context, semester)
+					if versions == nil {
+						versions = []publish.IndexEntry{}
+					}
+					return versions, err
+				},
+			},
+			"faculties": &gql.Field{
+				Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(facultyType))),
+				Args: withArgs(versionArgs, gql.FieldConfigArgument{
+					"name": &gql.ArgumentConfig{Type: gql.String},
+				}),
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					c, err := h.catalog(p.Context, p.Args)
+					if err != nil {
+						return nil, err
+					}
+					name, _ := p.Args["name"].(string)
+					result := []repy.Faculty{}
+					for _, f := range *c {
+						if name == "" || f.Name == name {
+							result = append(result, f)
+						}
+					}
+					return result, nil
+				},
+			},
+			"courses": &gql.Field{
+				Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(courseType))),
+				Args: withArgs(versionArgs, courseArgs, gql.FieldConfigArgument{
+					"faculty": &gql.ArgumentConfig{Type: gql.String},
+				}),
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					c, err := h.catalog(p.Context, p.Args)
+					if err != nil {
+						return nil, err
+					}
+					filter, err := courseFilter(p.Args)
+					if err != nil {
+						return nil, err
+					}
+					return c.Courses(filter), nil
+				},
+			},
+			"course": &gql.Field{
+				Type:        courseType,
+				Description: "The first appearance of a course, or null if there is none",
+				Args: withArgs(versionArgs, gql.FieldConfigArgument{
+					"id": &gql.ArgumentConfig{Type: gql.NewNonNull(gql.String)},
+				}),
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					c, err := h.catalog(p.Context, p.Args)
+					if err != nil {
+						return nil, err
+					}
+					filter, err := courseFilter(p.Args)
+					if err != nil {
+						return nil, err
+					}
+					if courses := c.Courses(filter); len(courses) > 0 {
+						return courses[0], nil
+					}
+					return nil, nil
+				},
+			},
+		},
+	})
+	return gql.NewSchema(gql.SchemaConfig{Query: query})
+}
@@ -0,0 +1,134 @@
+package repy
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// WeeklyInterval is a recurring weekly time interval, such as the time of an
+// Event. It starts at Start and ends just before End.
+type WeeklyInterval struct {
+	Day   time.Weekday         `json:"day"`
+	Start MinutesSinceMidnight `json:"start"`
+	End   MinutesSinceMidnight `json:"end"`
+}
+
+// ClockInterval is a WeeklyInterval whose times marshal to JSON as "HH:MM"
+// strings; see ClockTime.
+type ClockInterval struct {
+	Day   time.Weekday `json:"day"`
+	Start ClockTime    `json:"start"`
+	End   ClockTime    `json:"end"`
+}
+
+// Clock returns i as a ClockInterval.
+func (i WeeklyInterval) Clock() ClockInterval {
+	return ClockInterval{Day: i.Day, Start: ClockTime(i.Start), End: ClockTime(i.End)}
+}
+
+// Weekly returns i as a WeeklyInterval.
+func (i ClockInterval) Weekly() WeeklyInterval {
+	return WeeklyInterval{Day: i.Day, Start: MinutesSinceMidnight(i.Start), End: MinutesSinceMidnight(i.End)}
+}
+
+// Interval returns the weekly interval in which e takes place.
+func (e Event) Interval() WeeklyInterval {
+	return WeeklyInterval{Day: e.Day, Start: e.StartMinute, End: e.EndMinute}
+}
+
+func (i WeeklyInterval) String() string {
+	return fmt.Sprintf("%s %s-%s", i.Day, i.Start, i.End)
+}
+
+// Empty returns whether i doesn't contain any time.
+func (i WeeklyInterval) Empty() bool {
+	return i.End <= i.Start
+}
+
+// Length returns the length of i, in minutes.
+func (i WeeklyInterval) Length() MinutesSinceMidnight {
+	if i.Empty() {
+		return 0
+	}
+	return i.End - i.Start
+}
+
+// Before returns whether i starts before other in the week; intervals starting
+// at the same time are ordered by their end.
+func (i WeeklyInterval) Before(other WeeklyInterval) bool {
+	if i.Day != other.Day {
+		return i.Day < other.Day
+	}
+	if i.Start != other.Start {
+		return i.Start < other.Start
+	}
+	return i.End < other.End
+}
+
+// Overlaps returns whether i and other share any time.
+func (i WeeklyInterval) Overlaps(other WeeklyInterval) bool {
+	return !i.Intersect(other).Empty()
+}
+
+// Intersect returns the time shared by i and other, which is empty if there
+// isn't any.
+func (i WeeklyInterval) Intersect(other WeeklyInterval) WeeklyInterval {
+	if i.Day != other.Day {
+		return WeeklyInterval{Day: i.Day}
+	}
+	result := i
+	if other.Start > result.Start {
+		result.Start = other.Start
+	}
+	if other.End < result.End {
+		result.End = other.End
+	}
+	return result
+}
+
+// Union returns the time covered by any of intervals, as a sorted list of
+// non-empty intervals, none of which overlap or touch.
+func Union(intervals []WeeklyInterval) []WeeklyInterval {
+	var sorted []WeeklyInterval
+	for _, i := range intervals {
+		if !i.Empty() {
+			sorted = append(sorted, i)
+		}
+	}
+	sort.Slice(sorted, func(a, b int) bool { return sorted[a].Before(sorted[b]) })
+
+	var result []WeeklyInterval
+	for _, i := range sorted {
+		if n := len(result); n > 0 && result[n-1].Day == i.Day && i.Start <= result[n-1].End {
+			if i.End > result[n-1].End {
+				result[n-1].End = i.End
+			}
+			continue
+		}
+		result = append(result, i)
+	}
+	return result
+}
+
+// Subtract returns the time covered by from but not by remove, as a sorted
+// list of non-empty intervals, none of which overlap or touch.
+func Subtract(from, remove []WeeklyInterval) []WeeklyInterval {
+	remove = Union(remove)
+	var result []WeeklyInterval
+	for _, i := range Union(from) {
+		for _, r := range remove {
+			if !i.Overlaps(r) {
+				continue
+			}
+			if before := (WeeklyInterval{Day: i.Day, Start: i.Start, End: r.Start}); !before.Empty() {
+				result = append(result, before)
+			}
+			i.Start = r.End
+		}
+		if !i.Empty() {
+			result = append(result, i)
+		}
+	}
+	return result
+}
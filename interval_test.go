@@ -0,0 +1,113 @@
+package repy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWeeklyIntervalIntersect(t *testing.T) {
+	testCases := []struct {
+		a, b        WeeklyInterval
+		want        WeeklyInterval
+		wantOverlap bool
+	}{
+		{
+			a:           WeeklyInterval{time.Sunday, 600, 720},
+			b:           WeeklyInterval{time.Sunday, 660, 780},
+			want:        WeeklyInterval{time.Sunday, 660, 720},
+			wantOverlap: true,
+		},
+		{
+			a:           WeeklyInterval{time.Sunday, 600, 720},
+			b:           WeeklyInterval{time.Sunday, 630, 660},
+			want:        WeeklyInterval{time.Sunday, 630, 660},
+			wantOverlap: true,
+		},
+		{
+			a:           WeeklyInterval{time.Sunday, 600, 720},
+			b:           WeeklyInterval{time.Sunday, 720, 780},
+			want:        WeeklyInterval{time.Sunday, 720, 720},
+			wantOverlap: false,
+		},
+		{
+			a:           WeeklyInterval{time.Sunday, 600, 720},
+			b:           WeeklyInterval{time.Monday, 600, 720},
+			want:        WeeklyInterval{Day: time.Sunday},
+			wantOverlap: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.a.Intersect(tc.b); got != tc.want {
+			t.Errorf("%s.Intersect(%s) = %s; want %s", tc.a, tc.b, got, tc.want)
+		}
+		if got := tc.a.Overlaps(tc.b); got != tc.wantOverlap {
+			t.Errorf("%s.Overlaps(%s) = %t; want %t", tc.a, tc.b, got, tc.wantOverlap)
+		}
+		if got := tc.b.Overlaps(tc.a); got != tc.wantOverlap {
+			t.Errorf("%s.Overlaps(%s) = %t; want %t", tc.b, tc.a, got, tc.wantOverlap)
+		}
+	}
+}
+
+func TestUnion(t *testing.T) {
+	got := Union([]WeeklyInterval{
+		{time.Monday, 600, 660},
+		{time.Sunday, 900, 960},
+		{time.Sunday, 600, 720},
+		{time.Sunday, 660, 690},
+		{time.Sunday, 720, 780},
+		{time.Monday, 800, 700},
+	})
+	want := []WeeklyInterval{
+		{time.Sunday, 600, 780},
+		{time.Sunday, 900, 960},
+		{time.Monday, 600, 660},
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Union diff -want +got:\n%s", d)
+	}
+}
+
+func TestSubtract(t *testing.T) {
+	testCases := []struct {
+		name         string
+		from, remove []WeeklyInterval
+		want         []WeeklyInterval
+	}{
+		{
+			name:   "middle",
+			from:   []WeeklyInterval{{time.Sunday, 480, 1080}},
+			remove: []WeeklyInterval{{time.Sunday, 600, 720}, {time.Sunday, 690, 780}, {time.Sunday, 840, 900}},
+			want:   []WeeklyInterval{{time.Sunday, 480, 600}, {time.Sunday, 780, 840}, {time.Sunday, 900, 1080}},
+		},
+		{
+			name:   "edges",
+			from:   []WeeklyInterval{{time.Sunday, 480, 1080}},
+			remove: []WeeklyInterval{{time.Sunday, 420, 540}, {time.Sunday, 1020, 1200}},
+			want:   []WeeklyInterval{{time.Sunday, 540, 1020}},
+		},
+		{
+			name:   "other days",
+			from:   []WeeklyInterval{{time.Sunday, 480, 600}, {time.Tuesday, 480, 600}},
+			remove: []WeeklyInterval{{time.Monday, 0, EndOfDay}, {time.Tuesday, 480, 600}},
+			want:   []WeeklyInterval{{time.Sunday, 480, 600}},
+		},
+		{
+			name: "nothing to remove",
+			from: []WeeklyInterval{{time.Sunday, 480, 600}, {time.Sunday, 540, 660}},
+			want: []WeeklyInterval{{time.Sunday, 480, 660}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Subtract(tc.from, tc.remove)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Subtract diff -want +got:\n%s", d)
+			}
+		})
+	}
+}
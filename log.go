@@ -0,0 +1,77 @@
+package repy
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/golang/glog"
+)
+
+// Logger receives structured log messages during parsing. Messages carry
+// attributes such as "line", "faculty" and "courseID" where known.
+//
+// A *slog.Logger has a suitable Log method; see SlogLogger.
+type Logger interface {
+	Log(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr)
+
+	// Flush will automatically be called after parsing is complete
+	Flush()
+}
+
+// SlogLogger is a Logger which sends messages to an slog.Handler.
+type SlogLogger struct {
+	Handler slog.Handler
+}
+
+// Log implements Logger.Log
+func (s SlogLogger) Log(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	slog.New(s.Handler).LogAttrs(ctx, level, msg, attrs...)
+}
+
+// Flush implements Logger.Flush
+func (s SlogLogger) Flush() {}
+
+// GLogger is a Logger that uses glog. Debug messages are logged at verbosity
+// level 1.
+type GLogger struct{}
+
+// glogDepth skips GLogger.Log and the parser's logging helpers, so that glog
+// attributes messages to the parser code which logged them.
+const glogDepth = 3
+
+// Log implements Logger.Log
+func (g GLogger) Log(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	text := FormatMessage(msg, attrs)
+	switch {
+	case level >= slog.LevelError:
+		glog.ErrorDepth(glogDepth, text)
+	case level >= slog.LevelWarn:
+		glog.WarningDepth(glogDepth, text)
+	case level >= slog.LevelInfo:
+		glog.InfoDepth(glogDepth, text)
+	case bool(glog.V(1)):
+		glog.InfoDepth(glogDepth, text)
+	}
+}
+
+// Flush implements Logger.Flush
+func (g GLogger) Flush() {
+	glog.Flush()
+}
+
+// FormatMessage formats msg and attrs as a single line of text, for Loggers
+// which aren't structured, e.g. `Ignored line line=12 faculty="מדעי המחשב"`.
+func FormatMessage(msg string, attrs []slog.Attr) string {
+	var sb strings.Builder
+	sb.WriteString(msg)
+	for _, a := range attrs {
+		if a.Value.Kind() == slog.KindString {
+			fmt.Fprintf(&sb, " %s=%q", a.Key, a.Value.String())
+		} else {
+			fmt.Fprintf(&sb, " %s=%v", a.Key, a.Value)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,75 @@
+package repy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestFormatMessage(t *testing.T) {
+	got := FormatMessage("Ignored line", []slog.Attr{
+		slog.Uint64("line", 12),
+		slog.String("faculty", "מדעי המחשב"),
+	})
+	want := `Ignored line line=12 faculty="מדעי המחשב"`
+	if got != want {
+		t.Errorf("FormatMessage returned %q; want %q", got, want)
+	}
+}
+
+func TestSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := SlogLogger{Handler: slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn})}
+
+	if _, err := ReadFile(bytes.NewReader(readTestREPY(t, bigREPY)), logger); err != nil {
+		t.Fatalf("ReadFile returned error: %v", err)
+	}
+
+	records := 0
+	for _, line := range bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n")) {
+		var record struct {
+			Level    string
+			Line     uint
+			Category WarningCategory
+			Faculty  string
+		}
+		if err := json.Unmarshal(line, &record); err != nil {
+			t.Fatalf("Failed to unmarshal %q: %v", line, err)
+		}
+		records++
+		if record.Level != "WARN" || record.Line == 0 || record.Category == "" || record.Faculty == "" {
+			t.Errorf("Unexpected log record %s", line)
+		}
+	}
+	if records == 0 {
+		t.Errorf("No warnings were logged")
+	}
+}
+
+// recordingLogger remembers the levels of logged messages.
+type recordingLogger struct {
+	levels  []slog.Level
+	flushed bool
+}
+
+func (r *recordingLogger) Log(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	r.levels = append(r.levels, level)
+}
+
+func (r *recordingLogger) Flush() { r.flushed = true }
+
+func TestLoggerLevels(t *testing.T) {
+	data := readTestREPY(t, bigREPY)
+	var logger recordingLogger
+	if _, err := ReadFile(bytes.NewReader(data[:len(data)/2]), &logger); err == nil {
+		t.Fatalf("ReadFile of truncated input succeeded")
+	}
+	if !logger.flushed {
+		t.Errorf("Logger wasn't flushed")
+	}
+	if last := logger.levels[len(logger.levels)-1]; last != slog.LevelError {
+		t.Errorf("Last message has level %v; want %v", last, slog.LevelError)
+	}
+}
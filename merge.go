@@ -0,0 +1,165 @@
+package repy
+
+import (
+	"sort"
+)
+
+// CourseAppearance identifies where a course appears in the catalogs passed to
+// Merge. Catalog is the index of the catalog among them.
+type CourseAppearance struct {
+	Catalog  int    `json:"catalog"`
+	Faculty  string `json:"faculty"`
+	Semester string `json:"semester"`
+}
+
+// CrossListing is a course which appears in more than one faculty.
+type CrossListing struct {
+	CourseID  CourseID `json:"courseId"`
+	Faculties []string `json:"faculties"`
+}
+
+// MergeConflict is a course which appears more than once, with differing
+// contents.
+type MergeConflict struct {
+	CourseID CourseID `json:"courseId"`
+
+	// Appearances starts with the first appearance of the course, followed by
+	// those which differ from it.
+	Appearances []CourseAppearance `json:"appearances"`
+
+	// Fields lists the JSON names of the fields which differ, e.g. "groups" or
+	// "testDates".
+	Fields []string `json:"fields"`
+}
+
+// MergeReport describes courses which appear more than once in the catalogs
+// passed to Merge. Both lists are sorted by course ID.
+type MergeReport struct {
+	CrossListed []CrossListing  `json:"crossListed"`
+	Conflicts   []MergeConflict `json:"conflicts"`
+}
+
+// Merge combines catalogs into one. Faculties with the same name and semester
+// are combined, in order of first appearance, and each course appears at most
+// once per faculty; of several appearances within a faculty, the first is
+// kept. Courses listed in several faculties are kept in each of them, with
+// their own contents, and reported as cross-listed. Whenever a course differs
+// from its first appearance, this is reported as a conflict rather than
+// resolved. Nil catalogs are skipped.
+func Merge(catalogs ...*Catalog) (*Catalog, *MergeReport) {
+	type facultyKey struct{ name, semester string }
+
+	result := Catalog{}
+	facultyIndex := map[facultyKey]int{}
+	inFaculty := map[facultyKey]map[CourseID]bool{}
+
+	first := map[CourseID]*Course{}
+	conflicts := map[CourseID]*MergeConflict{}
+	facultyNames := map[CourseID][]string{}
+
+	for ci, c := range catalogs {
+		if c == nil {
+			continue
+		}
+		for _, f := range *c {
+			key := facultyKey{f.Name, f.Semester}
+			idx, ok := facultyIndex[key]
+			if !ok {
+				idx = len(result)
+				facultyIndex[key] = idx
+				inFaculty[key] = map[CourseID]bool{}
+				result = append(result, Faculty{Name: f.Name, Semester: f.Semester, Source: f.Source})
+			}
+
+			for i := range f.Courses {
+				course := &f.Courses[i]
+				appearance := CourseAppearance{Catalog: ci, Faculty: f.Name, Semester: f.Semester}
+
+				if prev, ok := first[course.ID]; !ok {
+					first[course.ID] = course
+					conflicts[course.ID] = &MergeConflict{
+						CourseID:    course.ID,
+						Appearances: []CourseAppearance{appearance},
+					}
+				} else if fields := differingFields(prev, course); len(fields) > 0 {
+					conflict := conflicts[course.ID]
+					conflict.Appearances = append(conflict.Appearances, appearance)
+					conflict.Fields = mergeFieldNames(conflict.Fields, fields)
+				}
+
+				if !containsString(facultyNames[course.ID], f.Name) {
+					facultyNames[course.ID] = append(facultyNames[course.ID], f.Name)
+				}
+
+				if !inFaculty[key][course.ID] {
+					inFaculty[key][course.ID] = true
+					result[idx].Courses = append(result[idx].Courses, *course)
+				}
+			}
+		}
+	}
+
+	report := &MergeReport{}
+	for id, names := range facultyNames {
+		if len(names) > 1 {
+			report.CrossListed = append(report.CrossListed, CrossListing{CourseID: id, Faculties: names})
+		}
+	}
+	for _, conflict := range conflicts {
+		if len(conflict.Fields) > 0 {
+			report.Conflicts = append(report.Conflicts, *conflict)
+		}
+	}
+	sort.Slice(report.CrossListed, func(i, j int) bool {
+		return report.CrossListed[i].CourseID < report.CrossListed[j].CourseID
+	})
+	sort.Slice(report.Conflicts, func(i, j int) bool {
+		return report.Conflicts[i].CourseID < report.Conflicts[j].CourseID
+	})
+
+	return &result, report
+}
+
+// differingFields returns the JSON names of the fields which differ between a
+// and b, which have the same ID.
+func differingFields(a, b *Course) []string {
+	fields := []struct {
+		name string
+		a, b interface{}
+	}{
+		{"name", a.Name, b.Name},
+		{"academicPoints", a.AcademicPoints, b.AcademicPoints},
+		{"lecturerInCharge", a.LecturerInCharge, b.LecturerInCharge},
+		{"weeklyHours", a.WeeklyHours, b.WeeklyHours},
+		{"testDates", a.TestDates, b.TestDates},
+		{"groups", a.Groups, b.Groups},
+		{"notes", a.Notes, b.Notes},
+	}
+
+	var result []string
+	for _, f := range fields {
+		if !sameJSON(f.a, f.b) {
+			result = append(result, f.name)
+		}
+	}
+	return result
+}
+
+// mergeFieldNames adds the names in add which aren't already in names.
+func mergeFieldNames(names, add []string) []string {
+	for _, n := range add {
+		if !containsString(names, n) {
+			names = append(names, n)
+		}
+	}
+	return names
+}
+
+func containsString(list []string, s string) bool {
+	for _, x := range list {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package repy
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMerge(t *testing.T) {
+	course := func(id CourseID, groupIDs ...uint) Course {
+		c := Course{ID: id, Name: "course"}
+		for _, g := range groupIDs {
+			c.Groups = append(c.Groups, Group{ID: g})
+		}
+		return c
+	}
+
+	main := &Catalog{
+		{Name: "CS", Semester: "S", Courses: []Course{course("234114", 10), course("234117", 10), course("234114", 10)}},
+		{Name: "Math", Semester: "S", Courses: []Course{course("104031", 10), course("234117", 10)}},
+	}
+	extra := &Catalog{
+		{Name: "CS", Semester: "S", Courses: []Course{course("234114", 10, 11), course("236363", 10)}},
+		{Name: "Sports", Semester: "S", Courses: []Course{course("394800", 10)}},
+	}
+
+	got, report := Merge(main, nil, extra)
+
+	want := &Catalog{
+		{Name: "CS", Semester: "S", Courses: []Course{course("234114", 10), course("234117", 10), course("236363", 10)}},
+		{Name: "Math", Semester: "S", Courses: []Course{course("104031", 10), course("234117", 10)}},
+		{Name: "Sports", Semester: "S", Courses: []Course{course("394800", 10)}},
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Merged catalog diff -want +got:\n%s", d)
+	}
+
+	wantReport := &MergeReport{
+		CrossListed: []CrossListing{{CourseID: "234117", Faculties: []string{"CS", "Math"}}},
+		Conflicts: []MergeConflict{{
+			CourseID: "234114",
+			Appearances: []CourseAppearance{
+				{Catalog: 0, Faculty: "CS", Semester: "S"},
+				{Catalog: 2, Faculty: "CS", Semester: "S"},
+			},
+			Fields: []string{"groups"},
+		}},
+	}
+	if d := cmp.Diff(wantReport, report); d != "" {
+		t.Errorf("Merge report diff -want +got:\n%s", d)
+	}
+}
+
+func TestMergeVersions(t *testing.T) {
+	read := func(filename string) *Catalog {
+		c, err := ReadFile(bytes.NewReader(readTestREPY(t, filename)), nil)
+		if err != nil {
+			t.Fatalf("Failed to read %q: %v", filename, err)
+		}
+		return c
+	}
+	v1 := read("testdata/winter_2017_v1.repy")
+	v2 := read("testdata/winter_2017_v2.repy")
+
+	merged, report := Merge(v1, v2)
+
+	// Merging a catalog with itself changes nothing.
+	if self, selfReport := Merge(v1, v1); !sameJSON(self, v1) || len(selfReport.Conflicts) != 0 {
+		t.Errorf("Merging a catalog with itself changed it, or reported %d conflicts", len(selfReport.Conflicts))
+	}
+
+	var wantConflicts, added []CourseID
+	for _, change := range DiffCatalogs(v1, v2) {
+		switch {
+		case change.Old == nil:
+			added = append(added, change.ID)
+		case change.New != nil:
+			wantConflicts = append(wantConflicts, change.ID)
+		}
+	}
+	var gotConflicts []CourseID
+	for _, c := range report.Conflicts {
+		gotConflicts = append(gotConflicts, c.CourseID)
+	}
+	if d := cmp.Diff(wantConflicts, gotConflicts); d != "" {
+		t.Errorf("Conflicts diff -want +got:\n%s", d)
+	}
+
+	// The merged catalog keeps v1's version of each course, adding those only
+	// in v2.
+	var gotAdded []CourseID
+	for _, change := range DiffCatalogs(v1, merged) {
+		gotAdded = append(gotAdded, change.ID)
+	}
+	if d := cmp.Diff(added, gotAdded); d != "" {
+		t.Errorf("Courses added to v1 by merging diff -want +got:\n%s", d)
+	}
+}
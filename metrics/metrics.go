@@ -0,0 +1,149 @@
+// Package metrics exports Prometheus metrics about parsing REPY files and
+// about publishing updates.
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/lutzky/repy"
+	"github.com/lutzky/repy/publish"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "repy"
+
+// Parser holds metrics about parsed REPY files. The gauges describe the most
+// recent successful parse, so that a sudden drop in e.g. the amount of courses
+// can be alerted on; failures are counted separately. Skipped courses are
+// counted as warnings in the "skipped_course" category.
+type Parser struct {
+	duration  prometheus.Histogram
+	lines     prometheus.Gauge
+	faculties prometheus.Gauge
+	courses   prometheus.Gauge
+	groups    prometheus.Gauge
+	warnings  *prometheus.CounterVec
+	failures  prometheus.Counter
+}
+
+// NewParser creates parser metrics, registered with reg.
+func NewParser(reg prometheus.Registerer) *Parser {
+	f := promauto.With(reg)
+	gauge := func(name, help string) prometheus.Gauge {
+		return f.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace, Subsystem: "parser", Name: name, Help: help,
+		})
+	}
+	return &Parser{
+		duration: f.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace, Subsystem: "parser", Name: "duration_seconds",
+			Help:    "Time taken to parse REPY files.",
+			Buckets: prometheus.ExponentialBuckets(0.01, 2, 12),
+		}),
+		lines:     gauge("lines", "Lines read from the last parsed REPY file."),
+		faculties: gauge("faculties", "Faculties in the last parsed REPY file."),
+		courses:   gauge("courses", "Courses in the last parsed REPY file."),
+		groups:    gauge("groups", "Groups in the last parsed REPY file."),
+		warnings: f.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Subsystem: "parser", Name: "warnings_total",
+			Help: "Warnings logged while parsing REPY files, by category.",
+		}, []string{"category"}),
+		failures: f.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace, Subsystem: "parser", Name: "failures_total",
+			Help: "REPY files which failed to parse.",
+		}),
+	}
+}
+
+// Observe records the statistics of a single parse, which failed if err isn't
+// nil. The gauges are only set for successful parses, as the statistics of
+// failed ones are partial.
+func (m *Parser) Observe(s repy.Stats, err error) {
+	m.duration.Observe(s.Duration.Seconds())
+	for category, n := range s.Warnings {
+		m.warnings.WithLabelValues(string(category)).Add(float64(n))
+	}
+	if err != nil {
+		m.failures.Inc()
+		return
+	}
+	m.lines.Set(float64(s.Lines))
+	m.faculties.Set(float64(s.Faculties))
+	m.courses.Set(float64(s.Courses))
+	m.groups.Set(float64(s.Groups))
+}
+
+// Results of an update, for Updates.ObserveResult.
+const (
+	ResultNew       = "new"
+	ResultUnchanged = "unchanged"
+	ResultError     = "error"
+)
+
+// Updates holds metrics about downloading and publishing REPY updates.
+type Updates struct {
+	downloadDuration     prometheus.Histogram
+	downloadBytes        prometheus.Gauge
+	results              *prometheus.CounterVec
+	storageWriteFailures prometheus.Counter
+}
+
+// NewUpdates creates update metrics, registered with reg.
+func NewUpdates(reg prometheus.Registerer) *Updates {
+	f := promauto.With(reg)
+	m := &Updates{
+		downloadDuration: f.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace, Subsystem: "update", Name: "download_duration_seconds",
+			Help:    "Time taken to download REPFILE.zip, including retries.",
+			Buckets: prometheus.ExponentialBuckets(0.1, 2, 10),
+		}),
+		downloadBytes: f.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace, Subsystem: "update", Name: "download_bytes",
+			Help: "Size of the last downloaded REPFILE.zip.",
+		}),
+		results: f.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Subsystem: "update", Name: "results_total",
+			Help: "Updates by result: new, unchanged or error.",
+		}, []string{"result"}),
+		storageWriteFailures: f.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace, Subsystem: "update", Name: "storage_write_failures_total",
+			Help: "Failed writes to storage.",
+		}),
+	}
+	for _, result := range []string{ResultNew, ResultUnchanged, ResultError} {
+		m.results.WithLabelValues(result)
+	}
+	return m
+}
+
+// ObserveDownload records a successful download of size bytes, which took d.
+func (m *Updates) ObserveDownload(d time.Duration, size int) {
+	m.downloadDuration.Observe(d.Seconds())
+	m.downloadBytes.Set(float64(size))
+}
+
+// ObserveResult records the result of an update, which is one of ResultNew,
+// ResultUnchanged and ResultError.
+func (m *Updates) ObserveResult(result string) {
+	m.results.WithLabelValues(result).Inc()
+}
+
+// Store returns a publish.Store which counts failed writes to s.
+func (m *Updates) Store(s publish.Store) publish.Store {
+	return instrumentedStore{Store: s, failures: m.storageWriteFailures}
+}
+
+type instrumentedStore struct {
+	publish.Store
+	failures prometheus.Counter
+}
+
+func (s instrumentedStore) Write(ctx context.Context, name, contentType string, data []byte) error {
+	err := s.Store.Write(ctx, name, contentType, data)
+	if err != nil {
+		s.failures.Inc()
+	}
+	return err
+}
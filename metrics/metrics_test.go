@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lutzky/repy"
+	"github.com/lutzky/repy/publish"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestParser(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewParser(reg)
+
+	for _, step := range []struct {
+		courses int
+		err     error
+	}{
+		{100, nil},
+		{3, nil},
+		// Partial statistics of a failed parse mustn't look like a drop.
+		{1, errors.New("bad REPY")},
+	} {
+		m.Observe(repy.Stats{
+			Duration:  time.Second,
+			Lines:     1000,
+			Faculties: 2,
+			Courses:   step.courses,
+			Groups:    20,
+			Warnings: map[repy.WarningCategory]int{
+				repy.WarningSkippedCourse: 2,
+				repy.WarningIgnoredLine:   1,
+			},
+		}, step.err)
+	}
+
+	const want = `
+# HELP repy_parser_courses Courses in the last parsed REPY file.
+# TYPE repy_parser_courses gauge
+repy_parser_courses 3
+# HELP repy_parser_failures_total REPY files which failed to parse.
+# TYPE repy_parser_failures_total counter
+repy_parser_failures_total 1
+# HELP repy_parser_warnings_total Warnings logged while parsing REPY files, by category.
+# TYPE repy_parser_warnings_total counter
+repy_parser_warnings_total{category="ignored_line"} 3
+repy_parser_warnings_total{category="skipped_course"} 6
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want),
+		"repy_parser_courses", "repy_parser_failures_total", "repy_parser_warnings_total"); err != nil {
+		t.Error(err)
+	}
+}
+
+// failingStore fails all writes.
+type failingStore struct {
+	publish.Dir
+}
+
+func (failingStore) Write(ctx context.Context, name, contentType string, data []byte) error {
+	return errors.New("disk full")
+}
+
+func TestUpdates(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewUpdates(reg)
+
+	m.ObserveDownload(time.Second, 1234)
+	m.ObserveResult(ResultNew)
+	m.ObserveResult(ResultUnchanged)
+	m.ObserveResult(ResultUnchanged)
+
+	ctx := context.Background()
+	if err := m.Store(publish.Dir(t.TempDir())).Write(ctx, "a", "text/plain", nil); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if err := m.Store(failingStore{}).Write(ctx, "b", "text/plain", nil); err == nil {
+		t.Fatalf("Write to failingStore succeeded")
+	}
+
+	const want = `
+# HELP repy_update_download_bytes Size of the last downloaded REPFILE.zip.
+# TYPE repy_update_download_bytes gauge
+repy_update_download_bytes 1234
+# HELP repy_update_results_total Updates by result: new, unchanged or error.
+# TYPE repy_update_results_total counter
+repy_update_results_total{result="error"} 0
+repy_update_results_total{result="new"} 1
+repy_update_results_total{result="unchanged"} 2
+# HELP repy_update_storage_write_failures_total Failed writes to storage.
+# TYPE repy_update_storage_write_failures_total counter
+repy_update_storage_write_failures_total 1
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want),
+		"repy_update_download_bytes", "repy_update_results_total", "repy_update_storage_write_failures_total"); err != nil {
+		t.Error(err)
+	}
+}
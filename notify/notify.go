@@ -0,0 +1,221 @@
+// Package notify sends webhook notifications to subscribers of courses which
+// changed between REPY versions.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/lutzky/repy"
+	"github.com/pkg/errors"
+)
+
+// Subscription asks for changes to a course, or to one of its groups, to be
+// POSTed to URL.
+type Subscription struct {
+	ID       string        `json:"id"`
+	CourseID repy.CourseID `json:"courseId"`
+
+	// GroupID limits notifications to changes in a single group. Zero means
+	// any change in the course.
+	GroupID uint `json:"groupId,omitempty"`
+
+	URL string `json:"url"`
+
+	// Secret is the HMAC-SHA256 key used to sign payloads.
+	Secret string `json:"secret"`
+}
+
+// SignatureHeader carries the hex HMAC-SHA256 signature of the request body,
+// prefixed with "sha256=".
+const SignatureHeader = "X-Repy-Signature"
+
+// Sign returns the value of SignatureHeader for body, signed with secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks signature (the value of SignatureHeader) against body; it is
+// intended for receivers.
+func Verify(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
+
+// Payload is the JSON body POSTed to subscribers.
+type Payload struct {
+	SubscriptionID string        `json:"subscriptionId"`
+	CourseID       repy.CourseID `json:"courseId"`
+	GroupID        uint          `json:"groupId,omitempty"`
+
+	// OldVersion and NewVersion identify the REPY versions compared, typically
+	// by their SHA1 sums.
+	OldVersion string `json:"oldVersion"`
+	NewVersion string `json:"newVersion"`
+
+	// OldCourse and NewCourse are nil if the course was added or removed,
+	// respectively.
+	OldCourse *repy.Course `json:"oldCourse"`
+	NewCourse *repy.Course `json:"newCourse"`
+
+	// OldGroup and NewGroup are only set for subscriptions to a group.
+	OldGroup *repy.Group `json:"oldGroup,omitempty"`
+	NewGroup *repy.Group `json:"newGroup,omitempty"`
+}
+
+// Defaults for the corresponding Notifier fields.
+const (
+	DefaultTimeout = 10 * time.Second
+	DefaultRetries = 3
+	DefaultBackoff = time.Second
+)
+
+// Notifier delivers notifications. The zero value is usable.
+type Notifier struct {
+	// Client is used for requests. If nil, http.DefaultClient is used.
+	Client *http.Client
+
+	// Timeout applies to each attempt. Zero means DefaultTimeout.
+	Timeout time.Duration
+
+	// Retries is the amount of additional attempts made after failures. Zero
+	// means DefaultRetries; negative means no retries.
+	Retries int
+
+	// Backoff is the delay before the first retry, doubled for each
+	// subsequent retry. Zero means DefaultBackoff.
+	Backoff time.Duration
+}
+
+// Payloads returns the payloads due to each of subs, given the changes
+// between oldVersion and newVersion as returned by repy.DiffCatalogs.
+func Payloads(subs []Subscription, changes []repy.CourseChange, oldVersion, newVersion string) []Payload {
+	byCourse := map[repy.CourseID]repy.CourseChange{}
+	for _, c := range changes {
+		byCourse[c.ID] = c
+	}
+
+	var result []Payload
+	for _, sub := range subs {
+		change, ok := byCourse[sub.CourseID]
+		if !ok {
+			continue
+		}
+		p := Payload{
+			SubscriptionID: sub.ID,
+			CourseID:       sub.CourseID,
+			GroupID:        sub.GroupID,
+			OldVersion:     oldVersion,
+			NewVersion:     newVersion,
+			OldCourse:      change.Old,
+			NewCourse:      change.New,
+		}
+		if sub.GroupID != 0 {
+			if !change.GroupChanged(sub.GroupID) {
+				continue
+			}
+			p.OldGroup = change.Old.Group(sub.GroupID)
+			p.NewGroup = change.New.Group(sub.GroupID)
+		}
+		result = append(result, p)
+	}
+	return result
+}
+
+// Notify sends the relevant payloads to each of subs. All subscribers are
+// attempted; the returned error (if any) lists the failed ones.
+func (n *Notifier) Notify(ctx context.Context, subs []Subscription, changes []repy.CourseChange, oldVersion, newVersion string) error {
+	subsByID := map[string]Subscription{}
+	for _, s := range subs {
+		subsByID[s.ID] = s
+	}
+
+	var failures []string
+	for _, p := range Payloads(subs, changes, oldVersion, newVersion) {
+		if err := n.Send(ctx, subsByID[p.SubscriptionID], p); err != nil {
+			failures = append(failures, err.Error())
+		}
+	}
+
+	if len(failures) > 0 {
+		return errors.Errorf("%d notifications failed: %q", len(failures), failures)
+	}
+	return nil
+}
+
+// Send POSTs a single payload to sub, retrying failures.
+func (n *Notifier) Send(ctx context.Context, sub Subscription, p Payload) error {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal payload for subscription %q", sub.ID)
+	}
+
+	retries := n.Retries
+	if retries == 0 {
+		retries = DefaultRetries
+	}
+	backoff := n.Backoff
+	if backoff == 0 {
+		backoff = DefaultBackoff
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := n.post(ctx, sub, body)
+		if err == nil {
+			return nil
+		}
+		if attempt >= retries {
+			return errors.Wrapf(err, "subscription %q: failed after %d attempts", sub.ID, attempt+1)
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "subscription %q: gave up (last error: %v)", sub.ID, err)
+		case <-time.After(backoff << uint(attempt)):
+		}
+	}
+}
+
+func (n *Notifier) post(ctx context.Context, sub Subscription, body []byte) error {
+	timeout := n.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequest(http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrapf(err, "invalid request for %s", sub.URL)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(sub.Secret, body))
+
+	client := n.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to POST to %s", sub.URL)
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("POST %s: unexpected status %d %s", sub.URL, resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+	return nil
+}
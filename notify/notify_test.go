@@ -0,0 +1,169 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lutzky/repy"
+	"github.com/lutzky/repy/publish"
+	"github.com/pkg/errors"
+)
+
+// receiver is a stand-in for a subscriber, recording verified payloads. The
+// first failures requests to each path fail.
+type receiver struct {
+	t        *testing.T
+	secret   string
+	failures int
+
+	mu       sync.Mutex
+	attempts map[string]int
+	payloads []Payload
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.attempts[r.URL.Path]++
+	if rc.attempts[r.URL.Path] <= rc.failures {
+		http.Error(w, "try again", http.StatusServiceUnavailable)
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		rc.t.Errorf("Failed to read request body: %v", err)
+	}
+	if !Verify(rc.secret, body, r.Header.Get(SignatureHeader)) {
+		http.Error(w, "bad signature", http.StatusForbidden)
+		return
+	}
+	var p Payload
+	if err := json.Unmarshal(body, &p); err != nil {
+		rc.t.Errorf("Failed to unmarshal payload: %v", err)
+	}
+	rc.payloads = append(rc.payloads, p)
+}
+
+func newReceiver(t *testing.T, secret string, failures int) (*receiver, *httptest.Server) {
+	rc := &receiver{t: t, secret: secret, failures: failures, attempts: map[string]int{}}
+	server := httptest.NewServer(rc)
+	t.Cleanup(server.Close)
+	return rc, server
+}
+
+func testCatalogs() (oldCatalog, newCatalog *repy.Catalog) {
+	group := func(id uint, start repy.MinutesSinceMidnight) repy.Group {
+		return repy.Group{ID: id, Events: []repy.Event{{StartMinute: start}}}
+	}
+	return &repy.Catalog{{Courses: []repy.Course{
+		{ID: "000001", Groups: []repy.Group{group(10, 600), group(11, 600)}},
+		{ID: "000002"},
+	}}}, &repy.Catalog{{Courses: []repy.Course{
+		{ID: "000001", Groups: []repy.Group{group(10, 600), group(11, 720)}},
+	}}}
+}
+
+func TestNotify(t *testing.T) {
+	const secret = "s3cr3t"
+	rc, server := newReceiver(t, secret, 1)
+
+	subs := []Subscription{
+		{ID: "course1", CourseID: "000001", URL: server.URL + "/a", Secret: secret},
+		{ID: "group10", CourseID: "000001", GroupID: 10, URL: server.URL + "/b", Secret: secret},
+		{ID: "group11", CourseID: "000001", GroupID: 11, URL: server.URL + "/c", Secret: secret},
+		{ID: "course2", CourseID: "000002", URL: server.URL + "/d", Secret: secret},
+		{ID: "course3", CourseID: "000003", URL: server.URL + "/e", Secret: secret},
+	}
+
+	oldCatalog, newCatalog := testCatalogs()
+	changes := repy.DiffCatalogs(oldCatalog, newCatalog)
+
+	n := Notifier{Backoff: time.Millisecond}
+	if err := n.Notify(context.Background(), subs, changes, "v1", "v2"); err != nil {
+		t.Fatalf("Notify failed: %v", err)
+	}
+
+	var gotIDs []string
+	for _, p := range rc.payloads {
+		gotIDs = append(gotIDs, p.SubscriptionID)
+		if p.OldVersion != "v1" || p.NewVersion != "v2" {
+			t.Errorf("Payload %q has versions %q, %q; want v1, v2", p.SubscriptionID, p.OldVersion, p.NewVersion)
+		}
+	}
+	sort.Strings(gotIDs)
+	if d := cmp.Diff([]string{"course1", "course2", "group11"}, gotIDs); d != "" {
+		t.Errorf("Notified subscriptions diff -want +got:\n%s", d)
+	}
+
+	for _, p := range rc.payloads {
+		switch p.SubscriptionID {
+		case "group11":
+			if p.OldGroup == nil || p.NewGroup == nil || p.NewGroup.Events[0].StartMinute != 720 {
+				t.Errorf("Group payload has groups %+v, %+v", p.OldGroup, p.NewGroup)
+			}
+		case "course2":
+			if p.OldCourse == nil || p.NewCourse != nil {
+				t.Errorf("Removed course payload has courses %+v, %+v", p.OldCourse, p.NewCourse)
+			}
+		}
+	}
+}
+
+func TestNotifyBadSecret(t *testing.T) {
+	_, server := newReceiver(t, "right", 0)
+
+	subs := []Subscription{{ID: "sub", CourseID: "000001", URL: server.URL, Secret: "wrong"}}
+	oldCatalog, newCatalog := testCatalogs()
+
+	n := Notifier{Backoff: time.Millisecond, Retries: -1}
+	if err := n.Notify(context.Background(), subs, repy.DiffCatalogs(oldCatalog, newCatalog), "v1", "v2"); err == nil {
+		t.Errorf("Notify succeeded despite bad signature")
+	}
+}
+
+func TestStores(t *testing.T) {
+	stores := map[string]Store{
+		"MemoryStore": &MemoryStore{},
+		"ObjectStore": &ObjectStore{Objects: publish.Dir(t.TempDir()), Name: "subscriptions.json"},
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			for _, sub := range []Subscription{
+				{ID: "a", CourseID: "000001"},
+				{ID: "b", CourseID: "000002"},
+				{ID: "a", CourseID: "000003"},
+			} {
+				if err := store.Add(ctx, sub); err != nil {
+					t.Fatalf("Add(%+v) failed: %v", sub, err)
+				}
+			}
+			if err := store.Remove(ctx, "b"); err != nil {
+				t.Fatalf("Remove failed: %v", err)
+			}
+			if err := store.Remove(ctx, "b"); !errors.Is(err, ErrNoSuchSubscription) {
+				t.Errorf("Second Remove returned %v; want %v", err, ErrNoSuchSubscription)
+			}
+
+			got, err := store.List(ctx)
+			if err != nil {
+				t.Fatalf("List failed: %v", err)
+			}
+			if d := cmp.Diff([]Subscription{{ID: "a", CourseID: "000003"}}, got); d != "" {
+				t.Errorf("List() diff -want +got:\n%s", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/lutzky/repy/publish"
+	"github.com/pkg/errors"
+)
+
+// Store holds subscriptions.
+type Store interface {
+	// List returns all subscriptions.
+	List(ctx context.Context) ([]Subscription, error)
+
+	// Add adds sub, replacing any existing subscription with the same ID.
+	Add(ctx context.Context, sub Subscription) error
+
+	// Remove removes the subscription with the given ID.
+	Remove(ctx context.Context, id string) error
+}
+
+// ErrNoSuchSubscription is returned (wrapped) by Store.Remove.
+var ErrNoSuchSubscription = errors.New("no such subscription")
+
+// MemoryStore is a Store which keeps subscriptions in memory. The zero value
+// is usable.
+type MemoryStore struct {
+	mu   sync.Mutex
+	subs []Subscription
+}
+
+var _ Store = &MemoryStore{}
+
+// List implements Store.List.
+func (ms *MemoryStore) List(ctx context.Context) ([]Subscription, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	return append([]Subscription(nil), ms.subs...), nil
+}
+
+// Add implements Store.Add.
+func (ms *MemoryStore) Add(ctx context.Context, sub Subscription) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.subs = addSubscription(ms.subs, sub)
+	return nil
+}
+
+// Remove implements Store.Remove.
+func (ms *MemoryStore) Remove(ctx context.Context, id string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	var err error
+	ms.subs, err = removeSubscription(ms.subs, id)
+	return err
+}
+
+func addSubscription(subs []Subscription, sub Subscription) []Subscription {
+	for i := range subs {
+		if subs[i].ID == sub.ID {
+			subs[i] = sub
+			return subs
+		}
+	}
+	return append(subs, sub)
+}
+
+func removeSubscription(subs []Subscription, id string) ([]Subscription, error) {
+	for i := range subs {
+		if subs[i].ID == id {
+			return append(subs[:i], subs[i+1:]...), nil
+		}
+	}
+	return subs, errors.Wrapf(ErrNoSuchSubscription, "%q", id)
+}
+
+// ObjectStore is a Store which keeps subscriptions as a JSON file named Name
+// in Objects. Subscriptions include secrets, so Objects must not be public.
+// Concurrent modifications from several processes are not supported.
+type ObjectStore struct {
+	Objects publish.Store
+	Name    string
+
+	mu sync.Mutex
+}
+
+var _ Store = &ObjectStore{}
+
+func (s *ObjectStore) read(ctx context.Context) ([]Subscription, error) {
+	data, err := s.Objects.Read(ctx, s.Name)
+	if errors.Is(err, publish.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to read subscriptions from %q", s.Name)
+	}
+	var subs []Subscription
+	if err := json.Unmarshal(data, &subs); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal subscriptions from %q", s.Name)
+	}
+	return subs, nil
+}
+
+func (s *ObjectStore) write(ctx context.Context, subs []Subscription) error {
+	data, err := json.MarshalIndent(subs, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal subscriptions")
+	}
+	return s.Objects.Write(ctx, s.Name, "application/json", data)
+}
+
+// List implements Store.List.
+func (s *ObjectStore) List(ctx context.Context) ([]Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.read(ctx)
+}
+
+// Add implements Store.Add.
+func (s *ObjectStore) Add(ctx context.Context, sub Subscription) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	subs, err := s.read(ctx)
+	if err != nil {
+		return err
+	}
+	return s.write(ctx, addSubscription(subs, sub))
+}
+
+// Remove implements Store.Remove.
+func (s *ObjectStore) Remove(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	subs, err := s.read(ctx)
+	if err != nil {
+		return err
+	}
+	if subs, err = removeSubscription(subs, id); err != nil {
+		return err
+	}
+	return s.write(ctx, subs)
+}
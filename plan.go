@@ -0,0 +1,231 @@
+package repy
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// PlanVersion is the version of the plan format read and written by this
+// package. Plans of other versions are rejected by ReadPlan.
+const PlanVersion = 1
+
+// Plan is a student's plan of courses and groups, per semester, e.g.:
+//
+//	version: 1
+//	semesters:
+//	  - semester: חורף תשפ"ה
+//	    courses:
+//	      - id: 234114
+//	        groups: [10, 11]
+type Plan struct {
+	Version   int            `json:"version" yaml:"version"`
+	Semesters []PlanSemester `json:"semesters" yaml:"semesters"`
+}
+
+// PlanSemester lists the courses chosen for a single semester. Semester is
+// named as in Faculty.Semester.
+type PlanSemester struct {
+	Semester string       `json:"semester" yaml:"semester"`
+	Courses  []PlanCourse `json:"courses" yaml:"courses"`
+}
+
+// PlanCourse is a chosen course, along with its chosen groups.
+type PlanCourse struct {
+	ID     CourseID `json:"id" yaml:"id"`
+	Groups []uint   `json:"groups" yaml:"groups"`
+}
+
+// ReadPlan reads a plan in either YAML or JSON format.
+func ReadPlan(r io.Reader) (*Plan, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	// JSON is valid YAML.
+	var p Plan
+	if err := yaml.UnmarshalStrict(data, &p); err != nil {
+		return nil, errors.Wrap(err, "failed to decode plan")
+	}
+	if p.Version != PlanVersion {
+		return nil, errors.Errorf("unsupported plan version %d; want %d", p.Version, PlanVersion)
+	}
+	return &p, nil
+}
+
+// WritePlan writes p in YAML format.
+func WritePlan(w io.Writer, p *Plan) error {
+	data, err := yaml.Marshal(p)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode plan")
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// Semester returns the named semester of p, or nil if there isn't one.
+func (p *Plan) Semester(name string) *PlanSemester {
+	for i := range p.Semesters {
+		if p.Semesters[i].Semester == name {
+			return &p.Semesters[i]
+		}
+	}
+	return nil
+}
+
+// Selection returns the chosen groups of s.
+func (s PlanSemester) Selection() Selection {
+	var result Selection
+	for _, c := range s.Courses {
+		for _, g := range c.Groups {
+			result = append(result, GroupRef{CourseID: c.ID, GroupID: g})
+		}
+	}
+	return result
+}
+
+// PlanProblemKind classifies the problems found by ValidatePlan.
+type PlanProblemKind string
+
+// Problem kinds reported by ValidatePlan.
+const (
+	PlanUnknownCourse    PlanProblemKind = "unknown_course"
+	PlanUnknownGroup     PlanProblemKind = "unknown_group"
+	PlanMissingGroupType PlanProblemKind = "missing_group_type"
+	PlanTimeClash        PlanProblemKind = "time_clash"
+	PlanExamClash        PlanProblemKind = "exam_clash"
+)
+
+// PlanProblem is a problem with a plan entry. GroupID is zero if the problem
+// doesn't concern a specific group.
+type PlanProblem struct {
+	Kind     PlanProblemKind `json:"kind"`
+	CourseID CourseID        `json:"courseId"`
+	GroupID  uint            `json:"groupId,omitempty"`
+	Message  string          `json:"message"`
+}
+
+func (p PlanProblem) String() string {
+	where := fmt.Sprintf("course %s", p.CourseID)
+	if p.GroupID != 0 {
+		where += fmt.Sprintf(", group %d", p.GroupID)
+	}
+	return fmt.Sprintf("%s: %s: %s", where, p.Kind, p.Message)
+}
+
+// PlanReport is the result of validating a semester of a plan.
+type PlanReport struct {
+	Semester string        `json:"semester"`
+	Problems []PlanProblem `json:"problems"`
+
+	// AcademicPoints and WeeklyHours are totals over the known courses of the
+	// semester.
+	AcademicPoints float32     `json:"academicPoints"`
+	WeeklyHours    WeeklyHours `json:"weeklyHours"`
+}
+
+// ValidatePlan resolves s against c, reporting unknown courses and groups,
+// courses missing a group of one of their group types (e.g. a course with
+// tutorials, none of which was chosen), overlapping groups and exams on the
+// same day.
+func ValidatePlan(c *Catalog, s PlanSemester) PlanReport {
+	report := PlanReport{Semester: s.Semester, Problems: []PlanProblem{}}
+	courses := c.coursesByID()
+
+	var known []CourseID
+	for _, pc := range s.Courses {
+		course, ok := courses[pc.ID]
+		if !ok {
+			report.Problems = append(report.Problems, PlanProblem{
+				Kind:     PlanUnknownCourse,
+				CourseID: pc.ID,
+				Message:  "course isn't in the catalog",
+			})
+			continue
+		}
+		known = append(known, pc.ID)
+
+		report.AcademicPoints += course.AcademicPoints
+		report.WeeklyHours.Lecture += course.WeeklyHours.Lecture
+		report.WeeklyHours.Tutorial += course.WeeklyHours.Tutorial
+		report.WeeklyHours.Lab += course.WeeklyHours.Lab
+		report.WeeklyHours.Project += course.WeeklyHours.Project
+
+		chosen := map[GroupType]bool{}
+		for _, id := range pc.Groups {
+			g := course.Group(id)
+			if g == nil {
+				report.Problems = append(report.Problems, PlanProblem{
+					Kind:     PlanUnknownGroup,
+					CourseID: pc.ID,
+					GroupID:  id,
+					Message:  "group isn't in the catalog",
+				})
+				continue
+			}
+			chosen[g.Type] = true
+		}
+
+		var missing []string
+		seen := map[GroupType]bool{}
+		for _, g := range course.Groups {
+			if !chosen[g.Type] && !seen[g.Type] {
+				missing = append(missing, g.Type.String())
+			}
+			seen[g.Type] = true
+		}
+		if len(missing) > 0 {
+			report.Problems = append(report.Problems, PlanProblem{
+				Kind:     PlanMissingGroupType,
+				CourseID: pc.ID,
+				Message:  fmt.Sprintf("no %s group chosen", strings.Join(missing, " or ")),
+			})
+		}
+	}
+
+	events, _ := c.Resolve(s.Selection())
+	for _, clash := range Clashes(events) {
+		a, b := clash.First, clash.Second
+		report.Problems = append(report.Problems, PlanProblem{
+			Kind:     PlanTimeClash,
+			CourseID: a.Course.ID,
+			GroupID:  a.Group.ID,
+			Message: fmt.Sprintf("%s %s-%s overlaps course %s group %d (%s-%s)",
+				a.Event.Day, a.Event.StartMinute, a.Event.EndMinute,
+				b.Course.ID, b.Group.ID, b.Event.StartMinute, b.Event.EndMinute),
+		})
+	}
+
+	exams := analyzeCourseSet(courses, CourseSet{Name: s.Semester, Courses: known}, 1)
+	for _, conflict := range exams.SameDay {
+		a, b := conflict.First, conflict.Second
+		report.Problems = append(report.Problems, PlanProblem{
+			Kind:     PlanExamClash,
+			CourseID: a.CourseID,
+			Message: fmt.Sprintf("exam %d on %d/%d/%d is on the same day as that of course %s",
+				a.Moed+1, a.Date.Day, a.Date.Month, a.Date.Year, b.CourseID),
+		})
+	}
+
+	return report
+}
+
+// NewPlanProblems returns the problems of s against c which it didn't have
+// against previous, e.g. plan entries made invalid by a new REPY version.
+func NewPlanProblems(previous, c *Catalog, s PlanSemester) []PlanProblem {
+	old := map[PlanProblem]bool{}
+	for _, p := range ValidatePlan(previous, s).Problems {
+		old[p] = true
+	}
+	result := []PlanProblem{}
+	for _, p := range ValidatePlan(c, s).Problems {
+		if !old[p] {
+			result = append(result, p)
+		}
+	}
+	return result
+}
@@ -0,0 +1,173 @@
+package repy
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReadPlan(t *testing.T) {
+	want := &Plan{Version: 1, Semesters: []PlanSemester{{
+		Semester: `חורף תשפ"ה`,
+		Courses: []PlanCourse{
+			{ID: "234114", Groups: []uint{10, 11}},
+			{ID: "014003", Groups: []uint{20}},
+		},
+	}}}
+
+	testCases := []struct {
+		name    string
+		input   string
+		want    *Plan
+		wantErr string
+	}{
+		{
+			name: "yaml",
+			input: `
+version: 1
+semesters:
+  - semester: חורף תשפ"ה
+    courses:
+      - id: 234114
+        groups: [10, 11]
+      - id: "14003"
+        groups: [20]
+`,
+			want: want,
+		},
+		{
+			name: "json",
+			input: `{"version": 1, "semesters": [{"semester": "חורף תשפ\"ה", "courses": [
+				{"id": 234114, "groups": [10, 11]}, {"id": "014003", "groups": [20]}]}]}`,
+			want: want,
+		},
+		{
+			name:    "missing version",
+			input:   `semesters: []`,
+			wantErr: "unsupported plan version 0",
+		},
+		{
+			name:    "unknown field",
+			input:   "version: 1\nsemester: []",
+			wantErr: "failed to decode plan",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ReadPlan(strings.NewReader(tc.input))
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("ReadPlan() error = %v; want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadPlan() failed: %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Plan diff -want +got:\n%s", d)
+			}
+		})
+	}
+}
+
+func TestWritePlan(t *testing.T) {
+	p := &Plan{Version: PlanVersion, Semesters: []PlanSemester{{
+		Semester: "אביב",
+		Courses:  []PlanCourse{{ID: "014003", Groups: []uint{10}}},
+	}}}
+	var buf bytes.Buffer
+	if err := WritePlan(&buf, p); err != nil {
+		t.Fatalf("WritePlan failed: %v", err)
+	}
+	got, err := ReadPlan(&buf)
+	if err != nil {
+		t.Fatalf("ReadPlan failed: %v", err)
+	}
+	if d := cmp.Diff(p, got); d != "" {
+		t.Errorf("Plan diff -want +got:\n%s", d)
+	}
+}
+
+func planTestCatalog() *Catalog {
+	event := func(day time.Weekday, start, end MinutesSinceMidnight) Event {
+		return Event{Day: day, StartMinute: start, EndMinute: end}
+	}
+	return &Catalog{{Courses: []Course{
+		{
+			ID:             "234114",
+			AcademicPoints: 4,
+			WeeklyHours:    WeeklyHours{Lecture: 4, Tutorial: 2},
+			TestDates:      []Date{{2025, 2, 10}},
+			Groups: []Group{
+				{ID: 10, Type: Lecture, Events: []Event{event(time.Sunday, 600, 720)}},
+				{ID: 11, Type: Tutorial, Events: []Event{event(time.Monday, 600, 720)}},
+				{ID: 12, Type: Tutorial, Events: []Event{event(time.Tuesday, 600, 720)}},
+			},
+		},
+		{
+			ID:             "104031",
+			AcademicPoints: 5.5,
+			WeeklyHours:    WeeklyHours{Lecture: 4, Tutorial: 2},
+			TestDates:      []Date{{2025, 2, 10}},
+			Groups: []Group{
+				{ID: 10, Type: Lecture, Events: []Event{event(time.Sunday, 690, 780)}},
+				{ID: 11, Type: Tutorial, Events: []Event{event(time.Monday, 720, 780)}},
+			},
+		},
+	}}}
+}
+
+func TestValidatePlan(t *testing.T) {
+	s := PlanSemester{Semester: "אביב", Courses: []PlanCourse{
+		{ID: "234114", Groups: []uint{10, 13}},
+		{ID: "104031", Groups: []uint{10, 11}},
+		{ID: "999999", Groups: []uint{10}},
+	}}
+
+	got := ValidatePlan(planTestCatalog(), s)
+	want := PlanReport{
+		Semester: "אביב",
+		Problems: []PlanProblem{
+			{PlanUnknownGroup, "234114", 13, "group isn't in the catalog"},
+			{PlanMissingGroupType, "234114", 0, "no tutorial group chosen"},
+			{PlanUnknownCourse, "999999", 0, "course isn't in the catalog"},
+			{PlanTimeClash, "234114", 10, "Sunday 10:00-12:00 overlaps course 104031 group 10 (11:30-13:00)"},
+			{PlanExamClash, "234114", 0, "exam 1 on 10/2/2025 is on the same day as that of course 104031"},
+		},
+		AcademicPoints: 9.5,
+		WeeklyHours:    WeeklyHours{Lecture: 8, Tutorial: 4},
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("ValidatePlan diff -want +got:\n%s", d)
+	}
+}
+
+func TestNewPlanProblems(t *testing.T) {
+	s := PlanSemester{Courses: []PlanCourse{
+		{ID: "234114", Groups: []uint{10, 11}},
+		{ID: "104031", Groups: []uint{11}},
+	}}
+
+	previous := planTestCatalog()
+	c := planTestCatalog()
+	courses := (*c)[0].Courses
+	// The tutorials of 234114 were removed, and tutorial 11 of 104031 moved so
+	// that it overlaps the lecture of 234114. The lecture of 104031, which
+	// wasn't chosen, was already missing.
+	courses[0].Groups = courses[0].Groups[:1]
+	courses[1].Groups[1].Events[0] = Event{Day: time.Sunday, StartMinute: 660, EndMinute: 720}
+
+	got := NewPlanProblems(previous, c, s)
+	want := []PlanProblem{
+		{PlanUnknownGroup, "234114", 11, "group isn't in the catalog"},
+		{PlanTimeClash, "234114", 10, "Sunday 10:00-12:00 overlaps course 104031 group 11 (11:00-12:00)"},
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("NewPlanProblems diff -want +got:\n%s", d)
+	}
+}
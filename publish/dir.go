@@ -0,0 +1,85 @@
+package publish
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// Dir is a Store which writes files into a local directory. Content types are
+// not stored.
+type Dir string
+
+var _ Store = Dir("")
+
+func (d Dir) path(name string) string {
+	return filepath.Join(string(d), filepath.Base(name))
+}
+
+// Write implements Store.Write. Files are replaced atomically, so readers
+// never observe partially-written files.
+func (d Dir) Write(ctx context.Context, name, contentType string, data []byte) error {
+	if err := os.MkdirAll(string(d), 0755); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(string(d), "."+filepath.Base(name)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), d.path(name))
+}
+
+// Read implements Store.Read.
+func (d Dir) Read(ctx context.Context, name string) ([]byte, error) {
+	data, err := ioutil.ReadFile(d.path(name))
+	if os.IsNotExist(err) {
+		return nil, errors.Wrapf(ErrNotExist, "%q", name)
+	}
+	return data, err
+}
+
+// Exists implements Store.Exists.
+func (d Dir) Exists(ctx context.Context, name string) (bool, error) {
+	_, err := os.Stat(d.path(name))
+	switch {
+	case err == nil:
+		return true, nil
+	case os.IsNotExist(err):
+		return false, nil
+	default:
+		return false, errors.Wrapf(err, "couldn't check if %q exists", name)
+	}
+}
+
+// List implements Store.List. A missing directory is treated as empty.
+func (d Dir) List(ctx context.Context) ([]string, error) {
+	infos, err := ioutil.ReadDir(string(d))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var result []string
+	for _, info := range infos {
+		if info.Mode().IsRegular() {
+			result = append(result, info.Name())
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,438 @@
+// Package publish stores REPY files, along with their ISO8859-8 and JSON
+// conversions, and maintains an index of all stored versions. It is used both
+// by the AppEngine app and by "repy-convert watch".
+package publish
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lutzky/repy"
+	"github.com/lutzky/repy/recode"
+	"github.com/lutzky/repy/writerlogger"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// ErrNotExist is returned (wrapped) by Store.Read for missing objects.
+var ErrNotExist = errors.New("object does not exist")
+
+// ParseError is returned (wrapped) by Publish if the REPY file fails to parse,
+// as opposed to failing to be stored.
+type ParseError struct {
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the error returned by the parser.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Store is where published files are written, such as a cloud storage bucket
+// or a local directory.
+type Store interface {
+	// Write creates or replaces the named object.
+	Write(ctx context.Context, name, contentType string, data []byte) error
+
+	// Read returns the contents of the named object.
+	Read(ctx context.Context, name string) ([]byte, error)
+
+	// Exists checks whether the named object exists.
+	Exists(ctx context.Context, name string) (bool, error)
+
+	// List returns the names of all objects.
+	List(ctx context.Context) ([]string, error)
+}
+
+// Names of files written by Publish, other than those named after the SHA1
+// sum of the REPY file.
+const (
+	IndexFileName    = "catalog.json"
+	ParseLogFileName = "latest.parse.log"
+)
+
+// Options control Publish.
+type Options struct {
+	// NoCache forces rebuilding the index from scratch, rather than reusing
+	// entries from the existing one.
+	NoCache bool
+
+	// Now returns the time at which a new REPY file is first published. If
+	// nil, time.Now is used.
+	Now func() time.Time
+
+	// ParseStats, if not nil, is filled in with the statistics of parsing the
+	// REPY file, even if parsing fails.
+	ParseStats *repy.Stats
+
+	// Logger, if not nil, receives log messages about publishing. Messages
+	// from parsing the REPY file are written to ParseLogFileName instead.
+	Logger repy.Logger
+}
+
+func (o Options) log(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	if o.Logger != nil {
+		o.Logger.Log(ctx, level, msg, attrs...)
+	}
+}
+
+// Result describes a successful Publish.
+type Result struct {
+	// SHA1Sum is the hex SHA1 sum of the published REPY file.
+	SHA1Sum string
+
+	// New is true if this REPY file hasn't been published before.
+	New bool
+
+	Catalog *repy.Catalog
+
+	// Previous is the catalog parsed (by this version of the parser) from the
+	// REPY file which was latest before this one was published, and
+	// PreviousSHA1Sum is its sum. These are only set for New results, if there
+	// was a previous file.
+	Previous        *repy.Catalog
+	PreviousSHA1Sum string
+}
+
+// Publish writes the REPY file in data to store, as follows:
+//
+//	<sha1>.repy, latest.repy: The original, cp862-encoded file
+//	<sha1>.txt, latest.txt: The file recoded to ISO8859-8
+//	<sha1>.timestamp: The time at which the file was first published
+//	<sha1>.json, latest.json: The parsed catalog
+//	latest.parse.log: The log of parsing the file
+//	catalog.json: An Index of all published files
+//
+// Files named after the SHA1 sum are only written if the REPY file wasn't
+// published before.
+func Publish(ctx context.Context, store Store, data []byte, opts Options) (*Result, error) {
+	if opts.Logger != nil {
+		defer opts.Logger.Flush()
+	}
+
+	sum := fmt.Sprintf("%x", sha1.Sum(data))
+	opts.log(ctx, slog.LevelInfo, "Publishing REPY", slog.String("sha1sum", sum))
+
+	result := &Result{SHA1Sum: sum}
+
+	baseFileName := sum + ".repy"
+	exists, err := store.Exists(ctx, baseFileName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't check if %q already exists", baseFileName)
+	}
+	if exists {
+		opts.log(ctx, slog.LevelInfo, "REPY was already published", slog.String("file", baseFileName))
+	}
+	result.New = !exists
+
+	if result.New {
+		// This must happen before the latest files are overwritten.
+		result.Previous, result.PreviousSHA1Sum = readLatest(ctx, store, opts)
+	}
+
+	if err := writeAllREPYFiles(ctx, store, sum, data, result.New, opts); err != nil {
+		return nil, errors.Wrap(err, "failed to write REPY files")
+	}
+
+	if result.Catalog, err = parseJSONAndWrite(ctx, store, sum, data, opts); err != nil {
+		return nil, errors.Wrap(err, "failed to complete JSON parsing")
+	}
+
+	opts.log(ctx, slog.LevelDebug, "Writing index", slog.String("file", IndexFileName))
+	if err := writeIndex(ctx, store, opts); err != nil {
+		return nil, errors.Wrap(err, "failed to write index")
+	}
+
+	return result, nil
+}
+
+// readLatest returns the latest published catalog and its SHA1 sum, or nil if
+// there isn't one. The catalog is parsed again from the REPY file, rather than
+// read from its JSON, so that it can be compared with the new one even if the
+// parser changed since it was published.
+func readLatest(ctx context.Context, store Store, opts Options) (*repy.Catalog, string) {
+	data, err := store.Read(ctx, "latest.repy")
+	if err != nil {
+		opts.log(ctx, slog.LevelInfo, "No previous REPY file", slog.String("error", err.Error()))
+		return nil, ""
+	}
+	sum := fmt.Sprintf("%x", sha1.Sum(data))
+
+	catalog, err := repy.ReadFileContext(ctx, bytes.NewReader(data), repy.ReadOptions{})
+	if err != nil {
+		opts.log(ctx, slog.LevelWarn, "Failed to parse previous catalog", slog.String("error", err.Error()))
+		return nil, ""
+	}
+	return catalog, sum
+}
+
+func writeAllREPYFiles(ctx context.Context, store Store, sum string, data []byte, isNew bool, opts Options) error {
+	repyBytesISO8859_8, err := recode.Recode(charmap.CodePage862, charmap.ISO8859_8, data)
+	if err != nil {
+		return errors.Wrap(err, "failed to convert REPY to ISO8859-8")
+	}
+
+	destinations := []struct {
+		filename      string
+		contentType   string
+		data          []byte
+		onlyIfMissing bool
+	}{
+		{sum + ".repy", "text/plain; charset=cp862", data, true},
+		{sum + ".txt", "text/plain; charset=iso8859-8", repyBytesISO8859_8, true},
+		{"latest.txt", "text/plain; charset=iso8859-8", repyBytesISO8859_8, false},
+		{"latest.repy", "text/plain; charset=cp862", data, false},
+	}
+
+	var g errgroup.Group
+	for _, dest := range destinations {
+		dest := dest
+		if dest.onlyIfMissing && !isNew {
+			continue
+		}
+		g.Go(func() error {
+			opts.log(ctx, slog.LevelDebug, "Writing file", slog.String("file", dest.filename), slog.String("contentType", dest.contentType))
+			if err := store.Write(ctx, dest.filename, dest.contentType, dest.data); err != nil {
+				return errors.Wrapf(err, "failed to write %q", dest.filename)
+			}
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	if isNew {
+		now := time.Now
+		if opts.Now != nil {
+			now = opts.Now
+		}
+		f := sum + ".timestamp"
+		opts.log(ctx, slog.LevelDebug, "Writing timestamp", slog.String("file", f))
+		timestamp := fmt.Sprintf("%s\n", now().UTC().Format(time.UnixDate))
+		if err := store.Write(ctx, f, "text/plain", []byte(timestamp)); err != nil {
+			return errors.Wrapf(err, "couldn't write timestamp %q", f)
+		}
+	}
+
+	return nil
+}
+
+func parseJSONAndWrite(ctx context.Context, store Store, sum string, data []byte, opts Options) (*repy.Catalog, error) {
+	var parseLog bytes.Buffer
+	defer func() {
+		if err := store.Write(ctx, ParseLogFileName, "text/plain; charset=utf-8", parseLog.Bytes()); err != nil {
+			opts.log(ctx, slog.LevelError, "Failed to write parse log", slog.String("file", ParseLogFileName), slog.String("error", err.Error()))
+		}
+	}()
+
+	catalog, err := repy.ReadFileContext(ctx, bytes.NewReader(data), repy.ReadOptions{
+		Logger: writerlogger.Logger{W: &parseLog},
+		Stats:  opts.ParseStats,
+	})
+	if err != nil {
+		fmt.Fprintf(&parseLog, "Read returned error: %v\n", err)
+		return nil, errors.Wrap(&ParseError{Err: err}, "failed to read catalog")
+	}
+
+	jsonBytes, err := json.MarshalIndent(catalog, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to format JSON")
+	}
+	jsonBytes = append(jsonBytes, '\n')
+
+	for _, filename := range []string{sum + ".json", "latest.json"} {
+		if err := store.Write(ctx, filename, "application/json", jsonBytes); err != nil {
+			return nil, errors.Wrapf(err, "failed to write %q", filename)
+		}
+	}
+
+	return catalog, nil
+}
+
+// Index is the format of IndexFileName.
+type Index struct {
+	Entries []IndexEntry
+}
+
+// IndexEntry describes a single published REPY file.
+type IndexEntry struct {
+	Sha1Sum   string
+	Original  string
+	Iso8859_8 string
+	Parsed    string
+	TimeStamp time.Time
+	Semester  string
+}
+
+// ReadIndex reads the index from store.
+func ReadIndex(ctx context.Context, store Store) (*Index, error) {
+	data, err := store.Read(ctx, IndexFileName)
+	if err != nil {
+		return nil, err
+	}
+	var index Index
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal %q", IndexFileName)
+	}
+	return &index, nil
+}
+
+func writeIndex(ctx context.Context, store Store, opts Options) error {
+	entries, err := getAllEntries(ctx, store, opts)
+	if err != nil {
+		return errors.Wrap(err, "failed to get timestamps and sums")
+	}
+
+	jsonBytes, err := json.Marshal(Index{Entries: entries})
+	if err != nil {
+		return errors.Wrap(err, "failed to format JSON")
+	}
+
+	if err := store.Write(ctx, IndexFileName, "application/json", jsonBytes); err != nil {
+		return errors.Wrapf(err, "failed to write JSON to %q", IndexFileName)
+	}
+
+	return nil
+}
+
+func loadCachedIndex(ctx context.Context, store Store, opts Options) map[string]IndexEntry {
+	if opts.NoCache {
+		opts.log(ctx, slog.LevelDebug, "Bypassing cached index")
+		return nil
+	}
+
+	opts.log(ctx, slog.LevelDebug, "Reading cached index", slog.String("file", IndexFileName))
+
+	index, err := ReadIndex(ctx, store)
+	if err != nil {
+		opts.log(ctx, slog.LevelWarn, "Failed to read cached index", slog.String("file", IndexFileName), slog.String("error", err.Error()))
+		return nil
+	}
+
+	result := map[string]IndexEntry{}
+	for _, entry := range index.Entries {
+		result[entry.Sha1Sum] = entry
+	}
+	return result
+}
+
+func getAllEntries(ctx context.Context, store Store, opts Options) ([]IndexEntry, error) {
+	sums, err := getExistingSHA1Sums(ctx, store)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list existing REPY data")
+	}
+
+	cache := loadCachedIndex(ctx, store, opts)
+
+	var g errgroup.Group
+	var mu sync.Mutex
+	results := []IndexEntry{}
+
+	for _, sum := range sums {
+		sum := sum
+		g.Go(func() error {
+			entry, ok := cache[sum]
+			if !ok {
+				ts, err := getTimeStampForSHA1Sum(ctx, store, sum)
+				if err != nil {
+					opts.log(ctx, slog.LevelError, "Couldn't get timestamp", slog.String("sha1sum", sum), slog.String("error", err.Error()))
+					return err
+				}
+				entry = IndexEntry{
+					Sha1Sum:   sum,
+					TimeStamp: ts,
+					Semester:  getSemesterForSHA1Sum(ctx, store, sum, opts),
+				}
+			}
+			entry.Original = sum + ".repy"
+			entry.Iso8859_8 = sum + ".txt"
+			entry.Parsed = sum + ".json"
+
+			mu.Lock()
+			results = append(results, entry)
+			mu.Unlock()
+			return nil
+		})
+	}
+
+	err = g.Wait()
+	return results, err
+}
+
+var repyFileRegexp = regexp.MustCompile(`^[0-9a-f]{40}\.repy$`)
+
+func getExistingSHA1Sums(ctx context.Context, store Store) ([]string, error) {
+	names, err := store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []string{}
+	for _, name := range names {
+		if repyFileRegexp.MatchString(name) {
+			result = append(result, strings.TrimSuffix(name, ".repy"))
+		}
+	}
+	return result, nil
+}
+
+func getTimeStampForSHA1Sum(ctx context.Context, store Store, sha1sum string) (time.Time, error) {
+	filename := sha1sum + ".timestamp"
+	data, err := store.Read(ctx, filename)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "couldn't read from %q", filename)
+	}
+
+	timestamp := strings.TrimSpace(string(data))
+
+	t, err := time.Parse(time.UnixDate, timestamp)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "couldn't parse time %q", timestamp)
+	}
+
+	return t, nil
+}
+
+func getSemesterForSHA1Sum(ctx context.Context, store Store, sha1sum string, opts Options) string {
+	filename := sha1sum + ".json"
+	data, err := store.Read(ctx, filename)
+	if err != nil {
+		opts.log(ctx, slog.LevelWarn, "Failed to read catalog", slog.String("file", filename), slog.String("error", err.Error()))
+		return ""
+	}
+
+	catalog := repy.Catalog{}
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		opts.log(ctx, slog.LevelWarn, "Failed to unmarshal catalog", slog.String("file", filename), slog.String("error", err.Error()))
+		return ""
+	}
+
+	if len(catalog) == 0 {
+		return "No faculties"
+	}
+
+	semester := catalog[0].Semester
+
+	for _, faculty := range catalog {
+		if faculty.Semester != semester {
+			return "INCONSISTENT"
+		}
+	}
+
+	return semester
+}
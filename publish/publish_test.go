@@ -0,0 +1,236 @@
+package publish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"log/slog"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lutzky/repy"
+	"github.com/pkg/errors"
+)
+
+func readTestREPY(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := ioutil.ReadFile("../testdata/" + name)
+	if err != nil {
+		t.Fatalf("Couldn't read %q: %v", name, err)
+	}
+	return data
+}
+
+func TestPublish(t *testing.T) {
+	ctx := context.Background()
+	store := Dir(t.TempDir())
+
+	t1 := time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+
+	first := readTestREPY(t, "course_statistics.repy")
+	second := readTestREPY(t, "course_algebra_a_fewdetails.repy")
+
+	steps := []struct {
+		data         []byte
+		now          time.Time
+		wantNew      bool
+		wantPrevious int
+	}{
+		{first, t1, true, -1},
+		{first, t2, false, -1},
+		{second, t2, true, 0},
+	}
+
+	var sums []string
+	for i, step := range steps {
+		now := step.now
+		result, err := Publish(ctx, store, step.data, Options{Now: func() time.Time { return now }})
+		if err != nil {
+			t.Fatalf("Step %d: Publish failed: %v", i, err)
+		}
+		if result.New != step.wantNew {
+			t.Errorf("Step %d: New = %v; want %v", i, result.New, step.wantNew)
+		}
+		if step.wantPrevious < 0 {
+			if result.Previous != nil || result.PreviousSHA1Sum != "" {
+				t.Errorf("Step %d: Got previous version %q; want none", i, result.PreviousSHA1Sum)
+			}
+		} else if result.PreviousSHA1Sum != sums[step.wantPrevious] || result.Previous == nil {
+			t.Errorf("Step %d: Got previous version %q; want %q", i, result.PreviousSHA1Sum, sums[step.wantPrevious])
+		}
+		if step.wantNew {
+			sums = append(sums, result.SHA1Sum)
+		}
+	}
+
+	names, err := store.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	wantNames := []string{IndexFileName, "latest.json", ParseLogFileName, "latest.repy", "latest.txt"}
+	for _, sum := range sums {
+		for _, ext := range []string{".repy", ".txt", ".json", ".timestamp"} {
+			wantNames = append(wantNames, sum+ext)
+		}
+	}
+	sort.Strings(wantNames)
+	if d := cmp.Diff(wantNames, names); d != "" {
+		t.Errorf("Published files diff -want +got:\n%s", d)
+	}
+
+	latest, err := store.Read(ctx, "latest.repy")
+	if err != nil {
+		t.Fatalf("Failed to read latest.repy: %v", err)
+	}
+	if string(latest) != string(second) {
+		t.Errorf("latest.repy isn't the most recently published file")
+	}
+
+	index, err := ReadIndex(ctx, store)
+	if err != nil {
+		t.Fatalf("ReadIndex failed: %v", err)
+	}
+	sort.Slice(index.Entries, func(i, j int) bool {
+		return index.Entries[i].TimeStamp.Before(index.Entries[j].TimeStamp)
+	})
+
+	entry := func(sum string, ts time.Time, semester string) IndexEntry {
+		return IndexEntry{
+			Sha1Sum:   sum,
+			Original:  sum + ".repy",
+			Iso8859_8: sum + ".txt",
+			Parsed:    sum + ".json",
+			TimeStamp: ts,
+			Semester:  semester,
+		}
+	}
+	want := []IndexEntry{
+		entry(sums[0], t1, "חורף תשע\"ו"),
+		entry(sums[1], t2, "חורף תשע\"ו"),
+	}
+	if d := cmp.Diff(want, index.Entries); d != "" {
+		t.Errorf("Index diff -want +got:\n%s", d)
+	}
+}
+
+func TestPublishPreviousReparsed(t *testing.T) {
+	ctx := context.Background()
+	store := Dir(t.TempDir())
+
+	first := readTestREPY(t, "course_sport_sample.repy")
+	result, err := Publish(ctx, store, first, Options{})
+	if err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	// JSON published by an older parser, which lacks fields added since.
+	if err := store.Write(ctx, result.SHA1Sum+".json", "application/json", []byte(`[{"name": "old"}]`)); err != nil {
+		t.Fatalf("Failed to overwrite JSON: %v", err)
+	}
+
+	result, err = Publish(ctx, store, readTestREPY(t, "course_statistics.repy"), Options{})
+	if err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	want, err := repy.ReadFile(bytes.NewReader(first), nil)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if changes := repy.DiffCatalogs(want, result.Previous); len(changes) != 0 {
+		t.Errorf("Previous catalog differs from the parsed REPY file in %d courses", len(changes))
+	}
+}
+
+// failingWriteStore fails writes of failName.
+type failingWriteStore struct {
+	Dir
+	failName string
+}
+
+func (s failingWriteStore) Write(ctx context.Context, name, contentType string, data []byte) error {
+	if name == s.failName {
+		return errors.Errorf("failed to write %q", name)
+	}
+	return s.Dir.Write(ctx, name, contentType, data)
+}
+
+func TestPublishErrors(t *testing.T) {
+	ctx := context.Background()
+	valid := readTestREPY(t, "course_statistics.repy")
+	truncated := bytes.Join(bytes.SplitAfter(valid, []byte("\n"))[:18], nil)
+
+	testCases := []struct {
+		name         string
+		data         []byte
+		failName     string
+		wantParseErr bool
+	}{
+		{name: "ParseFailure", data: truncated, wantParseErr: true},
+		{name: "StorageFailure", data: valid, failName: "latest.json"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := failingWriteStore{Dir: Dir(t.TempDir()), failName: tc.failName}
+			var stats repy.Stats
+			_, err := Publish(ctx, store, tc.data, Options{ParseStats: &stats})
+			if err == nil {
+				t.Fatalf("Publish succeeded; want error")
+			}
+			var parseErr *ParseError
+			if got := errors.As(err, &parseErr); got != tc.wantParseErr {
+				t.Errorf("Publish returned %v, a ParseError: %t; want %t", err, got, tc.wantParseErr)
+			}
+			if stats.Lines == 0 {
+				t.Errorf("ParseStats weren't filled in")
+			}
+		})
+	}
+}
+
+func TestPublishLogger(t *testing.T) {
+	ctx := context.Background()
+	store := Dir(t.TempDir())
+	data := readTestREPY(t, "course_statistics.repy")
+
+	var buf bytes.Buffer
+	opts := Options{Logger: repy.SlogLogger{Handler: slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})}}
+	for i := 0; i < 2; i++ {
+		if _, err := Publish(ctx, store, data, opts); err != nil {
+			t.Fatalf("Publish %d failed: %v", i, err)
+		}
+	}
+
+	var msgs []string
+	for _, line := range bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n")) {
+		var record struct {
+			Msg     string
+			SHA1Sum string `json:"sha1sum"`
+			File    string
+		}
+		if err := json.Unmarshal(line, &record); err != nil {
+			t.Fatalf("Failed to unmarshal %q: %v", line, err)
+		}
+		if record.Msg == "Publishing REPY" && record.SHA1Sum == "" {
+			t.Errorf("Log record %s has no sha1sum", line)
+		}
+		if record.Msg == "Writing file" && record.File == "" {
+			t.Errorf("Log record %s has no file", line)
+		}
+		msgs = append(msgs, record.Msg)
+	}
+
+	for _, want := range []string{"Publishing REPY", "Writing file", "Writing index", "REPY was already published"} {
+		found := false
+		for _, msg := range msgs {
+			found = found || msg == want
+		}
+		if !found {
+			t.Errorf("%q wasn't logged; got %q", want, msgs)
+		}
+	}
+}
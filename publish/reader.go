@@ -0,0 +1,94 @@
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"github.com/lutzky/repy"
+	"github.com/pkg/errors"
+)
+
+// ErrNoSuchVersion is returned (wrapped) by Reader.Catalog if no published
+// version matches the request.
+var ErrNoSuchVersion = errors.New("no such version")
+
+// Reader reads published catalogs from a Store, for serving them. Catalogs are
+// cached in memory; published versions never change, so the cache is never
+// invalidated.
+type Reader struct {
+	store Store
+
+	mu       sync.Mutex
+	catalogs map[string]*repy.Catalog
+}
+
+// NewReader returns a Reader for the catalogs published in store.
+func NewReader(store Store) *Reader {
+	return &Reader{store: store, catalogs: map[string]*repy.Catalog{}}
+}
+
+// Versions returns the published versions, newest first. If semester isn't
+// empty, only versions of that semester are returned.
+func (r *Reader) Versions(ctx context.Context, semester string) ([]IndexEntry, error) {
+	index, err := ReadIndex(ctx, r.store)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read index")
+	}
+	var result []IndexEntry
+	for _, e := range index.Entries {
+		if semester == "" || e.Semester == semester {
+			result = append(result, e)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].TimeStamp.After(result[j].TimeStamp)
+	})
+	return result, nil
+}
+
+// Catalog returns a published catalog, along with its index entry. If version
+// isn't empty, it is the SHA1 sum of the requested version; otherwise, the
+// latest version is returned. If semester isn't empty, only versions of that
+// semester are considered.
+func (r *Reader) Catalog(ctx context.Context, version, semester string) (*repy.Catalog, *IndexEntry, error) {
+	entries, err := r.Versions(ctx, semester)
+	if err != nil {
+		return nil, nil, err
+	}
+	var entry *IndexEntry
+	for i := range entries {
+		if version == "" || entries[i].Sha1Sum == version {
+			entry = &entries[i]
+			break
+		}
+	}
+	if entry == nil {
+		if version != "" {
+			return nil, nil, errors.Wrapf(ErrNoSuchVersion, "%q", version)
+		}
+		return nil, nil, errors.Wrap(ErrNoSuchVersion, "no published catalogs")
+	}
+
+	r.mu.Lock()
+	c, ok := r.catalogs[entry.Sha1Sum]
+	r.mu.Unlock()
+	if ok {
+		return c, entry, nil
+	}
+
+	data, err := r.store.Read(ctx, entry.Parsed)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to read %q", entry.Parsed)
+	}
+	c = &repy.Catalog{}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to unmarshal %q", entry.Parsed)
+	}
+
+	r.mu.Lock()
+	r.catalogs[entry.Sha1Sum] = c
+	r.mu.Unlock()
+	return c, entry, nil
+}
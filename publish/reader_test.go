@@ -0,0 +1,64 @@
+package publish
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestReader(t *testing.T) {
+	ctx := context.Background()
+	store := Dir(t.TempDir())
+
+	t1 := time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC)
+	var sums []string
+	for i, name := range []string{"course_statistics.repy", "course_sport_sample.repy"} {
+		now := t1.Add(time.Duration(i) * time.Hour)
+		result, err := Publish(ctx, store, readTestREPY(t, name), Options{Now: func() time.Time { return now }})
+		if err != nil {
+			t.Fatalf("Publish(%q) failed: %v", name, err)
+		}
+		sums = append(sums, result.SHA1Sum)
+	}
+
+	r := NewReader(store)
+
+	testCases := []struct {
+		version, semester string
+		wantSum           string
+		wantErr           error
+	}{
+		{"", "", sums[1], nil},
+		{sums[0], "", sums[0], nil},
+		{"", `חורף תשע"ו`, sums[0], nil},
+		{sums[1], `חורף תשע"ו`, "", ErrNoSuchVersion},
+		{"nonexistent", "", "", ErrNoSuchVersion},
+	}
+
+	for _, tc := range testCases {
+		c, entry, err := r.Catalog(ctx, tc.version, tc.semester)
+		if tc.wantErr != nil {
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("Catalog(%q, %q) returned error %v; want %v", tc.version, tc.semester, err, tc.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Catalog(%q, %q) returned error: %v", tc.version, tc.semester, err)
+			continue
+		}
+		if entry.Sha1Sum != tc.wantSum || len(*c) == 0 {
+			t.Errorf("Catalog(%q, %q) returned version %q with %d faculties; want %q", tc.version, tc.semester, entry.Sha1Sum, len(*c), tc.wantSum)
+		}
+	}
+
+	versions, err := r.Versions(ctx, "")
+	if err != nil {
+		t.Fatalf("Versions failed: %v", err)
+	}
+	if len(versions) != 2 || versions[0].Sha1Sum != sums[1] {
+		t.Errorf("Versions returned %+v; want newest first", versions)
+	}
+}
@@ -0,0 +1,152 @@
+package repy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+const bigREPY = "testdata/winter_2019_v1.repy"
+
+func readTestREPY(t *testing.T, filename string) []byte {
+	t.Helper()
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("Couldn't read %q: %v", filename, err)
+	}
+	return data
+}
+
+func TestReadFileContextLimits(t *testing.T) {
+	data := readTestREPY(t, bigREPY)
+
+	testCases := []struct {
+		name    string
+		opts    ReadOptions
+		wantErr error
+	}{
+		{"NoLimits", ReadOptions{}, nil},
+		{"ExactSize", ReadOptions{MaxInputSize: int64(len(data))}, nil},
+		{"TooLarge", ReadOptions{MaxInputSize: int64(len(data)) - 1}, ErrInputTooLarge},
+		{"LongEnoughLines", ReadOptions{MaxLineLength: 200}, nil},
+		{"LineTooLong", ReadOptions{MaxLineLength: 20}, ErrLineTooLong},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ReadFileContext(context.Background(), bytes.NewReader(data), tc.opts)
+			if tc.wantErr == nil && err != nil {
+				t.Errorf("ReadFileContext(%+v) returned error: %v", tc.opts, err)
+			} else if tc.wantErr != nil && !errors.Is(err, tc.wantErr) {
+				t.Errorf("ReadFileContext(%+v) returned error %v; want %v", tc.opts, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadFileContextCancel(t *testing.T) {
+	data := readTestREPY(t, bigREPY)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	opts := ReadOptions{
+		Progress: func(p Progress) {
+			if p.Courses == 10 {
+				cancel()
+			}
+		},
+	}
+
+	_, err := ReadFileContext(ctx, bytes.NewReader(data), opts)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ReadFileContext returned %v; want %v", err, context.Canceled)
+	}
+}
+
+func TestReadFileContextProgress(t *testing.T) {
+	data := readTestREPY(t, bigREPY)
+
+	for _, concurrency := range []int{0, 4} {
+		t.Run(fmt.Sprintf("Concurrency%d", concurrency), func(t *testing.T) {
+			var reports []Progress
+			opts := ReadOptions{
+				Progress:    func(p Progress) { reports = append(reports, p) },
+				Concurrency: concurrency,
+			}
+
+			catalog, err := ReadFileContext(context.Background(), bytes.NewReader(data), opts)
+			if err != nil {
+				t.Fatalf("ReadFileContext returned error: %v", err)
+			}
+
+			wantCourses := 0
+			for _, f := range *catalog {
+				wantCourses += len(f.Courses)
+			}
+
+			if len(reports) != wantCourses+1 {
+				t.Fatalf("Got %d progress reports; want %d", len(reports), wantCourses+1)
+			}
+
+			for i := 1; i < len(reports); i++ {
+				prev, cur := reports[i-1], reports[i]
+				// Each course is reported as it is parsed, followed by a
+				// final report once parsing completes.
+				wantCourses := prev.Courses + 1
+				if i == len(reports)-1 {
+					wantCourses = prev.Courses
+				}
+				if cur.Bytes < prev.Bytes || cur.Lines < prev.Lines || cur.Courses != wantCourses {
+					t.Errorf("Progress went backwards or skipped courses: %+v -> %+v", prev, cur)
+				}
+			}
+
+			last := reports[len(reports)-1]
+			if last.Courses != wantCourses || last.Bytes != int64(len(data)) {
+				t.Errorf("Last progress report is %+v; want %d courses and %d bytes", last, wantCourses, len(data))
+			}
+		})
+	}
+}
+
+func TestReadFileContextStats(t *testing.T) {
+	data := readTestREPY(t, bigREPY)
+
+	var stats Stats
+	catalog, err := ReadFileContext(context.Background(), bytes.NewReader(data), ReadOptions{Stats: &stats})
+	if err != nil {
+		t.Fatalf("ReadFileContext returned error: %v", err)
+	}
+
+	want := Stats{Faculties: len(*catalog), Warnings: stats.Warnings}
+	for _, f := range *catalog {
+		want.Courses += len(f.Courses)
+		for _, c := range f.Courses {
+			want.Groups += len(c.Groups)
+		}
+	}
+	want.Lines = uint(bytes.Count(data, []byte("\n")))
+
+	got := stats
+	got.Duration = 0
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Stats diff -want +got:\n%s", d)
+	}
+	if stats.Duration <= 0 {
+		t.Errorf("Got non-positive duration %v", stats.Duration)
+	}
+
+	stats = Stats{}
+	if _, err := ReadFileContext(context.Background(), bytes.NewReader(data[:len(data)/2]), ReadOptions{Stats: &stats}); err == nil {
+		t.Fatalf("ReadFileContext of truncated input succeeded")
+	}
+	if stats.Lines == 0 || stats.Courses == 0 {
+		t.Errorf("Got stats %+v for failed parse; want lines and courses counted", stats)
+	}
+}
@@ -0,0 +1,285 @@
+// Package render draws weekly timetables of groups as SVG images, suitable for
+// printing. Days are laid out right-to-left, Sunday first, as is customary for
+// Hebrew timetables.
+package render
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lutzky/repy"
+	"github.com/lutzky/repy/bidi"
+)
+
+// Item is a group to draw, along with its course, which is used for labels.
+type Item struct {
+	Course *repy.Course
+	Group  *repy.Group
+}
+
+// Course returns items for all of the groups of c.
+func Course(c *repy.Course) []Item {
+	var result []Item
+	for i := range c.Groups {
+		result = append(result, Item{Course: c, Group: &c.Groups[i]})
+	}
+	return result
+}
+
+// Scheduled returns items for the groups of events, such as those returned by
+// repy.Catalog.Resolve, each appearing once.
+func Scheduled(events []repy.ScheduledEvent) []Item {
+	var result []Item
+	seen := map[repy.GroupRef]bool{}
+	for _, ev := range events {
+		if !seen[ev.Ref()] {
+			seen[ev.Ref()] = true
+			result = append(result, Item{Course: ev.Course, Group: ev.Group})
+		}
+	}
+	return result
+}
+
+// DefaultColors are the fill colors of blocks, by group type.
+var DefaultColors = map[repy.GroupType]string{
+	repy.Lecture:  "#9ec5ea",
+	repy.Tutorial: "#b5dea5",
+	repy.Lab:      "#f3d18f",
+	repy.Sport:    "#e2a8d9",
+}
+
+// Options control the layout of the timetable. Zero values are replaced with
+// defaults.
+type Options struct {
+	// Width is the width of the image, in pixels.
+	Width int
+
+	// HourHeight is the height of each hour row, in pixels.
+	HourHeight int
+
+	// Title is shown above the timetable, if set.
+	Title string
+
+	// Colors override DefaultColors for the group types they include.
+	Colors map[repy.GroupType]string
+
+	// VisualOrder reorders Hebrew labels for SVG renderers which lack bidi
+	// support, and would otherwise show them reversed.
+	VisualOrder bool
+}
+
+const (
+	defaultWidth      = 900
+	defaultHourHeight = 60
+	headerHeight      = 30
+	titleHeight       = 30
+	timeColumnWidth   = 50
+	fontSize          = 11
+	lineHeight        = fontSize + 3
+)
+
+var days = []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+
+var dayNames = map[time.Weekday]string{
+	time.Sunday:    "ראשון",
+	time.Monday:    "שני",
+	time.Tuesday:   "שלישי",
+	time.Wednesday: "רביעי",
+	time.Thursday:  "חמישי",
+	time.Friday:    "שישי",
+}
+
+var groupTypeNames = map[repy.GroupType]string{
+	repy.Lecture:  "הרצאה",
+	repy.Tutorial: "תרגול",
+	repy.Lab:      "מעבדה",
+	repy.Sport:    "ספורט",
+}
+
+// block is an event to draw. Overlapping events of a day share the width of
+// the column, each in its own lane.
+type block struct {
+	item  Item
+	event repy.Event
+	lane  int
+	lanes int
+}
+
+// layout assigns lanes to the blocks of a single day, so that overlapping
+// blocks are drawn side-by-side.
+func layout(blocks []*block) {
+	sort.SliceStable(blocks, func(i, j int) bool {
+		return blocks[i].event.StartMinute < blocks[j].event.StartMinute
+	})
+
+	// Blocks are laid out in clusters of transitively overlapping blocks,
+	// all of which share the same amount of lanes.
+	var cluster []*block
+	var laneEnds []repy.MinutesSinceMidnight
+	var clusterEnd repy.MinutesSinceMidnight
+	flush := func() {
+		for _, b := range cluster {
+			b.lanes = len(laneEnds)
+		}
+		cluster, laneEnds = nil, nil
+	}
+
+	for _, b := range blocks {
+		if len(cluster) > 0 && b.event.StartMinute >= clusterEnd {
+			flush()
+		}
+		b.lane = -1
+		for i, end := range laneEnds {
+			if end <= b.event.StartMinute {
+				b.lane = i
+				laneEnds[i] = b.event.EndMinute
+				break
+			}
+		}
+		if b.lane == -1 {
+			b.lane = len(laneEnds)
+			laneEnds = append(laneEnds, b.event.EndMinute)
+		}
+		if len(cluster) == 0 || b.event.EndMinute > clusterEnd {
+			clusterEnd = b.event.EndMinute
+		}
+		cluster = append(cluster, b)
+	}
+	flush()
+}
+
+func escape(s string) string {
+	var sb strings.Builder
+	xml.EscapeText(&sb, []byte(s))
+	return sb.String()
+}
+
+// SVG writes a weekly timetable of items to w. Rows span the whole hours in
+// which the events of items take place; events on Saturday aren't shown.
+func SVG(w io.Writer, items []Item, opts Options) error {
+	if opts.Width == 0 {
+		opts.Width = defaultWidth
+	}
+	if opts.HourHeight == 0 {
+		opts.HourHeight = defaultHourHeight
+	}
+	colors := map[repy.GroupType]string{}
+	for gt, c := range DefaultColors {
+		colors[gt] = c
+	}
+	for gt, c := range opts.Colors {
+		colors[gt] = c
+	}
+	text := func(s string) string {
+		if opts.VisualOrder {
+			s = bidi.Visual(s)
+		}
+		return escape(s)
+	}
+
+	byDay := map[time.Weekday][]*block{}
+	var first, last repy.MinutesSinceMidnight
+	found := false
+	for _, item := range items {
+		for _, ev := range item.Group.Events {
+			if ev.Day > time.Friday {
+				continue
+			}
+			byDay[ev.Day] = append(byDay[ev.Day], &block{item: item, event: ev})
+			if !found || ev.StartMinute < first {
+				first = ev.StartMinute
+			}
+			if !found || ev.EndMinute > last {
+				last = ev.EndMinute
+			}
+			found = true
+		}
+	}
+	for _, blocks := range byDay {
+		layout(blocks)
+	}
+
+	firstHour, lastHour := 8, 18
+	if found {
+		firstHour, lastHour = int(first)/60, (int(last)+59)/60
+	}
+
+	top := headerHeight
+	if opts.Title != "" {
+		top += titleHeight
+	}
+	height := top + (lastHour-firstHour)*opts.HourHeight
+	colWidth := float64(opts.Width-timeColumnWidth) / float64(len(days))
+
+	// Columns are right-to-left, with the time column on the right.
+	dayX := func(i int) float64 {
+		return float64(opts.Width-timeColumnWidth) - float64(i+1)*colWidth
+	}
+	minuteY := func(m repy.MinutesSinceMidnight) float64 {
+		return float64(top) + float64(int(m)-firstHour*60)*float64(opts.HourHeight)/60
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" direction="rtl" font-family="Arial, sans-serif" font-size="%d">`+"\n",
+		opts.Width, height, opts.Width, height, fontSize)
+	fmt.Fprintf(bw, `<rect width="%d" height="%d" fill="white"/>`+"\n", opts.Width, height)
+
+	if opts.Title != "" {
+		fmt.Fprintf(bw, `<text x="%d" y="%d" text-anchor="middle" font-size="%d" font-weight="bold">%s</text>`+"\n",
+			opts.Width/2, titleHeight*2/3, fontSize+5, text(opts.Title))
+	}
+
+	for h := firstHour; h <= lastHour; h++ {
+		y := minuteY(repy.MinutesSinceMidnight(h * 60))
+		fmt.Fprintf(bw, `<line x1="0" y1="%.1f" x2="%d" y2="%.1f" stroke="#ccc"/>`+"\n", y, opts.Width, y)
+		if h < lastHour {
+			fmt.Fprintf(bw, `<text x="%d" y="%.1f" text-anchor="middle">%02d:00</text>`+"\n",
+				opts.Width-timeColumnWidth/2, y+lineHeight, h)
+		}
+	}
+	for i, day := range days {
+		x := dayX(i)
+		fmt.Fprintf(bw, `<line x1="%.1f" y1="%d" x2="%.1f" y2="%d" stroke="#ccc"/>`+"\n", x+colWidth, top-headerHeight, x+colWidth, height)
+		fmt.Fprintf(bw, `<text x="%.1f" y="%d" text-anchor="middle" font-weight="bold">%s</text>`+"\n",
+			x+colWidth/2, top-headerHeight/3, text(dayNames[day]))
+	}
+
+	id := 0
+	for i, day := range days {
+		for _, b := range byDay[day] {
+			laneWidth := colWidth / float64(b.lanes)
+			// Lanes are right-to-left as well.
+			x := dayX(i) + colWidth - float64(b.lane+1)*laneWidth
+			y := minuteY(b.event.StartMinute)
+			h := minuteY(b.event.EndMinute) - y
+
+			labels := []string{
+				b.item.Course.Name,
+				fmt.Sprintf("%s %d", groupTypeNames[b.item.Group.Type], b.item.Group.ID),
+				fmt.Sprintf("%s-%s", b.event.StartMinute, b.event.EndMinute),
+			}
+			if b.event.Location != "" {
+				labels = append(labels, b.event.Location)
+			}
+
+			id++
+			fmt.Fprintf(bw, `<g><title>%s</title>`+"\n", text(fmt.Sprintf("%s %s", b.item.Course.ID, strings.Join(labels, ", "))))
+			fmt.Fprintf(bw, `<clipPath id="block%d"><rect x="%.1f" y="%.1f" width="%.1f" height="%.1f"/></clipPath>`+"\n", id, x, y, laneWidth, h)
+			fmt.Fprintf(bw, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" rx="3" fill="%s" stroke="#555"/>`+"\n",
+				x+1, y+1, laneWidth-2, h-2, colors[b.item.Group.Type])
+			fmt.Fprintf(bw, `<text clip-path="url(#block%d)" text-anchor="start">`, id)
+			for j, label := range labels {
+				fmt.Fprintf(bw, `<tspan x="%.1f" y="%.1f">%s</tspan>`, x+laneWidth-4, y+float64((j+1)*lineHeight), text(label))
+			}
+			fmt.Fprint(bw, "</text></g>\n")
+		}
+	}
+
+	fmt.Fprint(bw, "</svg>\n")
+	return bw.Flush()
+}
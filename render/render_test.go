@@ -0,0 +1,190 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lutzky/repy"
+)
+
+func TestLayout(t *testing.T) {
+	testCases := []struct {
+		name  string
+		times [][2]repy.MinutesSinceMidnight
+		// want is the lane and amount of lanes of each block, in order of
+		// start time.
+		want [][2]int
+	}{
+		{
+			name:  "separate",
+			times: [][2]repy.MinutesSinceMidnight{{600, 660}, {660, 720}},
+			want:  [][2]int{{0, 1}, {0, 1}},
+		},
+		{
+			name:  "overlapping",
+			times: [][2]repy.MinutesSinceMidnight{{600, 720}, {630, 690}},
+			want:  [][2]int{{0, 2}, {1, 2}},
+		},
+		{
+			name: "chain reuses lane",
+			// The third block starts after the first ends, but still overlaps
+			// the second, so all three share two lanes.
+			times: [][2]repy.MinutesSinceMidnight{{600, 660}, {630, 720}, {660, 690}, {720, 780}},
+			want:  [][2]int{{0, 2}, {1, 2}, {0, 2}, {0, 1}},
+		},
+		{
+			name:  "three way",
+			times: [][2]repy.MinutesSinceMidnight{{600, 720}, {600, 720}, {650, 700}},
+			want:  [][2]int{{0, 3}, {1, 3}, {2, 3}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var blocks []*block
+			for _, tm := range tc.times {
+				blocks = append(blocks, &block{event: repy.Event{StartMinute: tm[0], EndMinute: tm[1]}})
+			}
+			layout(blocks)
+			var got [][2]int
+			for _, b := range blocks {
+				got = append(got, [2]int{b.lane, b.lanes})
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Lanes diff -want +got:\n%s", d)
+			}
+		})
+	}
+}
+
+// rects returns the attributes of the rect elements in svg with the given
+// fill, failing if svg isn't well-formed.
+func rects(t *testing.T, svg []byte, fill string) []map[string]string {
+	t.Helper()
+	var result []map[string]string
+	d := xml.NewDecoder(bytes.NewReader(svg))
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return result
+		}
+		if err != nil {
+			t.Fatalf("Invalid SVG: %v", err)
+		}
+		if se, ok := tok.(xml.StartElement); ok && se.Name.Local == "rect" {
+			attrs := map[string]string{}
+			for _, a := range se.Attr {
+				attrs[a.Name.Local] = a.Value
+			}
+			if attrs["fill"] == fill {
+				result = append(result, attrs)
+			}
+		}
+	}
+}
+
+func TestSVG(t *testing.T) {
+	data, err := os.ReadFile("../testdata/course_biology_1.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var catalog repy.Catalog
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		t.Fatal(err)
+	}
+	course := &catalog[0].Courses[0]
+
+	var buf bytes.Buffer
+	if err := SVG(&buf, Course(course), Options{Title: "ביולוגיה"}); err != nil {
+		t.Fatalf("SVG failed: %v", err)
+	}
+	svg := buf.Bytes()
+
+	var events int
+	for _, g := range course.Groups {
+		events += len(g.Events)
+	}
+	lectures := rects(t, svg, DefaultColors[repy.Lecture])
+	if len(lectures) != events {
+		t.Errorf("Got %d lecture blocks; want %d", len(lectures), events)
+	}
+
+	for _, want := range []string{`direction="rtl"`, "ביולוגיה 1", "הרצאה 10", "ראשון", "שישי"} {
+		if !bytes.Contains(svg, []byte(want)) {
+			t.Errorf("SVG doesn't contain %q", want)
+		}
+	}
+	if bytes.Contains(svg, []byte("שבת")) {
+		t.Errorf("SVG contains a Saturday column")
+	}
+}
+
+func TestSVGOverlap(t *testing.T) {
+	course := &repy.Course{ID: "234114", Name: "מבוא", Groups: []repy.Group{
+		{ID: 10, Type: repy.Lecture, Events: []repy.Event{{Day: time.Sunday, StartMinute: 600, EndMinute: 720}}},
+		{ID: 11, Type: repy.Tutorial, Events: []repy.Event{{Day: time.Sunday, StartMinute: 660, EndMinute: 720}}},
+	}}
+
+	var buf bytes.Buffer
+	if err := SVG(&buf, Course(course), Options{Width: 650, VisualOrder: true}); err != nil {
+		t.Fatalf("SVG failed: %v", err)
+	}
+	svg := buf.Bytes()
+
+	lecture := rects(t, svg, DefaultColors[repy.Lecture])
+	tutorial := rects(t, svg, DefaultColors[repy.Tutorial])
+	if len(lecture) != 1 || len(tutorial) != 1 {
+		t.Fatalf("Got %d lecture and %d tutorial blocks; want 1 each", len(lecture), len(tutorial))
+	}
+
+	// The time column is 50px wide, leaving 100px per day, split into two
+	// lanes: on Sunday, the rightmost column, the lecture is on the right.
+	want := []map[string]string{
+		{"x": "551.0", "y": "31.0", "width": "48.0", "height": "118.0"},
+		{"x": "501.0", "y": "91.0", "width": "48.0", "height": "58.0"},
+	}
+	for i, r := range []map[string]string{lecture[0], tutorial[0]} {
+		got := map[string]string{}
+		for k := range want[i] {
+			got[k] = r[k]
+		}
+		if d := cmp.Diff(want[i], got); d != "" {
+			t.Errorf("Block %d diff -want +got:\n%s", i, d)
+		}
+	}
+
+	if !strings.Contains(buf.String(), "אובמ") {
+		t.Errorf("SVG with VisualOrder doesn't contain reordered course name")
+	}
+}
+
+func TestSVGPartialColors(t *testing.T) {
+	course := &repy.Course{ID: "234114", Name: "מבוא", Groups: []repy.Group{
+		{ID: 10, Type: repy.Lecture, Events: []repy.Event{{Day: time.Sunday, StartMinute: 600, EndMinute: 720}}},
+		{ID: 11, Type: repy.Tutorial, Events: []repy.Event{{Day: time.Monday, StartMinute: 660, EndMinute: 720}}},
+	}}
+
+	var buf bytes.Buffer
+	opts := Options{Colors: map[repy.GroupType]string{repy.Lecture: "#ff0000"}}
+	if err := SVG(&buf, Course(course), opts); err != nil {
+		t.Fatalf("SVG failed: %v", err)
+	}
+	svg := buf.Bytes()
+
+	if got := rects(t, svg, "#ff0000"); len(got) != 1 {
+		t.Errorf("Got %d blocks with the overridden lecture color; want 1", len(got))
+	}
+	if got := rects(t, svg, DefaultColors[repy.Tutorial]); len(got) != 1 {
+		t.Errorf("Got %d blocks with the default tutorial color; want 1", len(got))
+	}
+	if bytes.Contains(svg, []byte(`fill=""`)) {
+		t.Errorf("SVG has blocks without a color")
+	}
+}
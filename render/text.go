@@ -0,0 +1,160 @@
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lutzky/repy"
+	"github.com/lutzky/repy/bidi"
+	"github.com/mattn/go-runewidth"
+)
+
+// TextOptions control the layout of text timetables. Zero values are replaced
+// with defaults.
+type TextOptions struct {
+	// Width is the width of the timetable, in columns.
+	Width int
+
+	// Step is the length of each row, in minutes.
+	Step int
+
+	// Visual reorders Hebrew text for terminals which don't display it
+	// right-to-left by themselves.
+	Visual bool
+}
+
+const (
+	defaultTextWidth = 80
+	defaultStep      = 30
+	textTimeWidth    = 5
+	minCellWidth     = 6
+)
+
+// clashMarker starts cells in which events of several groups take place.
+const clashMarker = "!"
+
+// Text writes a weekly timetable of items to w, using box-drawing characters.
+// Like SVG, days are laid out right-to-left. Each event is labeled with its
+// course, group and location over its rows; cells of overlapping events are
+// marked with "!", and the clashes are listed below the timetable. It returns
+// the clashes.
+func Text(w io.Writer, items []Item, opts TextOptions) ([]repy.Clash, error) {
+	if opts.Width == 0 {
+		opts.Width = defaultTextWidth
+	}
+	if opts.Step == 0 {
+		opts.Step = defaultStep
+	}
+	display := func(s string) string { return s }
+	if opts.Visual {
+		display = bidi.Visual
+	}
+
+	var events []repy.ScheduledEvent
+	for _, item := range items {
+		for _, ev := range item.Group.Events {
+			if ev.Day <= time.Friday {
+				events = append(events, repy.ScheduledEvent{Course: item.Course, Group: item.Group, Event: ev})
+			}
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	if len(events) == 0 {
+		fmt.Fprintln(bw, "No events")
+		return nil, bw.Flush()
+	}
+
+	first, last := events[0].Event.StartMinute, events[0].Event.EndMinute
+	for _, ev := range events {
+		if ev.Event.StartMinute < first {
+			first = ev.Event.StartMinute
+		}
+		if ev.Event.EndMinute > last {
+			last = ev.Event.EndMinute
+		}
+	}
+	step := repy.MinutesSinceMidnight(opts.Step)
+	first -= first % step
+
+	// Each of the day columns and the time column has a border on its left,
+	// and the time column has another on its right.
+	cellWidth := (opts.Width - textTimeWidth - len(days) - 2) / len(days)
+	if cellWidth < minCellWidth {
+		cellWidth = minCellWidth
+	}
+
+	// cell fits s, in logical order, into a cell of the given width, aligned
+	// to the right.
+	cell := func(s string, width int) string {
+		s = display(runewidth.Truncate(s, width, "…"))
+		return strings.Repeat(" ", width-runewidth.StringWidth(s)) + s
+	}
+	border := func(left, middle, right string) {
+		bw.WriteString(left)
+		for range days {
+			bw.WriteString(strings.Repeat("─", cellWidth) + middle)
+		}
+		bw.WriteString(strings.Repeat("─", textTimeWidth) + right + "\n")
+	}
+
+	border("┌", "┬", "┐")
+	bw.WriteString("│")
+	for i := len(days) - 1; i >= 0; i-- {
+		bw.WriteString(cell(dayNames[days[i]], cellWidth) + "│")
+	}
+	bw.WriteString(strings.Repeat(" ", textTimeWidth) + "│\n")
+	border("├", "┼", "┤")
+
+	for t := first; t < last; t += step {
+		bw.WriteString("│")
+		for i := len(days) - 1; i >= 0; i-- {
+			var labels []string
+			var inSlot int
+			for _, ev := range events {
+				e := ev.Event
+				if !e.Interval().Overlaps(repy.WeeklyInterval{Day: days[i], Start: t, End: t + step}) {
+					continue
+				}
+				inSlot++
+				lines := []string{
+					ev.Course.Name,
+					fmt.Sprintf("%s %d", groupTypeNames[ev.Group.Type], ev.Group.ID),
+					e.Location,
+				}
+				// The row in which the event starts is its first line.
+				if line := int((t - (e.StartMinute - e.StartMinute%step)) / step); line < len(lines) && lines[line] != "" {
+					labels = append(labels, lines[line])
+				}
+			}
+			if inSlot > 1 {
+				bw.WriteString(clashMarker + cell(strings.Join(labels, "/"), cellWidth-len(clashMarker)) + "│")
+			} else {
+				bw.WriteString(cell(strings.Join(labels, "/"), cellWidth) + "│")
+			}
+		}
+		bw.WriteString(t.String() + "│\n")
+	}
+	border("└", "┴", "┘")
+
+	clashes := repy.Clashes(sortEvents(events))
+	for _, c := range clashes {
+		fmt.Fprintf(bw, "%s Clash on %s: %s\n", clashMarker, c.First.Event.Day, display(fmt.Sprintf("%s %s %d (%s-%s), %s %s %d (%s-%s)",
+			c.First.Course.ID, c.First.Course.Name, c.First.Group.ID, c.First.Event.StartMinute, c.First.Event.EndMinute,
+			c.Second.Course.ID, c.Second.Course.Name, c.Second.Group.ID, c.Second.Event.StartMinute, c.Second.Event.EndMinute)))
+	}
+	return clashes, bw.Flush()
+}
+
+// sortEvents sorts events by time, as in repy.Catalog.Resolve, so that clashes
+// are reported in order.
+func sortEvents(events []repy.ScheduledEvent) []repy.ScheduledEvent {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Event.Interval().Before(events[j].Event.Interval())
+	})
+	return events
+}
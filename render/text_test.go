@@ -0,0 +1,100 @@
+package render
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lutzky/repy"
+	"github.com/mattn/go-runewidth"
+)
+
+func TestText(t *testing.T) {
+	algebra := &repy.Course{ID: "104166", Name: "אלגברה", Groups: []repy.Group{
+		{ID: 10, Type: repy.Lecture, Events: []repy.Event{{Day: time.Sunday, StartMinute: 600, EndMinute: 720, Location: "אולמן 200"}}},
+	}}
+	intro := &repy.Course{ID: "234114", Name: "מבוא", Groups: []repy.Group{
+		{ID: 11, Type: repy.Tutorial, Events: []repy.Event{
+			{Day: time.Sunday, StartMinute: 690, EndMinute: 750},
+			{Day: time.Friday, StartMinute: 600, EndMinute: 630},
+		}},
+	}}
+	items := append(Course(algebra), Course(intro)...)
+
+	testCases := []struct {
+		name   string
+		visual bool
+		course string
+	}{
+		{"logical", false, "אלגברה"},
+		{"visual", true, "הרבגלא"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			clashes, err := Text(&buf, items, TextOptions{Width: 80, Visual: tc.visual})
+			if err != nil {
+				t.Fatalf("Text failed: %v", err)
+			}
+			lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+
+			// Borders and header, a row per half hour from 10:00 to 12:30,
+			// and a line per clash.
+			if want := 4 + 5 + 1; len(lines) != want {
+				t.Fatalf("Got %d lines; want %d:\n%s", len(lines), want, buf.String())
+			}
+			for i, line := range lines[:9] {
+				if w := runewidth.StringWidth(line); w != 79 {
+					t.Errorf("Line %d has width %d; want 79: %q", i, w, line)
+				}
+			}
+
+			// Sunday is the rightmost day, followed by the times.
+			cells := strings.Split(strings.Trim(lines[3], "│"), "│")
+			if len(cells) != 7 {
+				t.Fatalf("Got %d cells in %q; want 7", len(cells), lines[3])
+			}
+			if got := strings.TrimSpace(cells[5]); got != tc.course {
+				t.Errorf("First Sunday cell is %q; want %q", got, tc.course)
+			}
+			if got := cells[6]; got != "10:00" {
+				t.Errorf("First row is at %q; want 10:00", got)
+			}
+			if strings.TrimSpace(cells[0]) == "" {
+				t.Errorf("First Friday cell is empty; want the tutorial")
+			}
+
+			var clashRows []string
+			for _, line := range lines[3:8] {
+				cells := strings.Split(strings.Trim(line, "│"), "│")
+				if strings.HasPrefix(cells[5], clashMarker) {
+					clashRows = append(clashRows, cells[6])
+				}
+			}
+			if d := cmp.Diff([]string{"11:30"}, clashRows); d != "" {
+				t.Errorf("Clash rows diff -want +got:\n%s", d)
+			}
+
+			if len(clashes) != 1 {
+				t.Fatalf("Got %d clashes; want 1", len(clashes))
+			}
+			if !strings.HasPrefix(lines[9], clashMarker+" Clash on Sunday") {
+				t.Errorf("Last line is %q; want clash report", lines[9])
+			}
+		})
+	}
+}
+
+func TestTextEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	clashes, err := Text(&buf, nil, TextOptions{})
+	if err != nil || len(clashes) != 0 {
+		t.Fatalf("Text() = %v, %v; want no clashes", clashes, err)
+	}
+	if got := buf.String(); got != "No events\n" {
+		t.Errorf("Text() wrote %q; want %q", got, "No events\n")
+	}
+}
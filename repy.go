@@ -4,69 +4,206 @@ package repy
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"path"
 	"regexp"
 	"runtime"
-	"runtime/debug"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/lutzky/repy/bidi"
 
-	"github.com/golang/glog"
 	"github.com/pkg/errors"
-	"golang.org/x/text/encoding/charmap"
 )
 
-// Logger is an interface for passing a logger to ReadFile
-type Logger interface {
-	Infof(format string, args ...interface{})
-	Warningf(format string, args ...interface{})
-
-	// Flush will automatically be called after parsing is complete
-	Flush()
+// ReadFile reads repyReader, parses it as REPY, and returns a Catalog. If
+// logger is not nil, log messages will be sent to it.
+func ReadFile(repyReader io.Reader, logger Logger) (*Catalog, error) {
+	return ReadFileContext(context.Background(), repyReader, ReadOptions{Logger: logger})
 }
 
-// GLogger is a Logger that uses glog
-type GLogger struct{}
+var (
+	// ErrInputTooLarge is returned (wrapped) by ReadFileContext when the input
+	// exceeds ReadOptions.MaxInputSize.
+	ErrInputTooLarge = errors.New("REPY input too large")
 
-// Infof implements Logger.Infof
-func (g GLogger) Infof(format string, args ...interface{}) {
-	glog.InfoDepth(3, fmt.Sprintf(format, args...))
+	// ErrLineTooLong is returned (wrapped) by ReadFileContext when a line
+	// exceeds ReadOptions.MaxLineLength.
+	ErrLineTooLong = errors.New("REPY line too long")
+)
+
+// ReadOptions control the behavior of ReadFileContext. The zero value is
+// usable, and imposes no limits.
+type ReadOptions struct {
+	// Logger, if not nil, receives log messages during parsing.
+	Logger Logger
+
+	// MaxInputSize is the maximum amount of bytes read from the input. Zero
+	// means unlimited.
+	MaxInputSize int64
+
+	// MaxLineLength is the maximum length of a single line, in bytes, after
+	// decoding into UTF-8 (which typically doubles the size of Hebrew text).
+	// Zero means bufio.MaxScanTokenSize.
+	MaxLineLength int
+
+	// Progress, if not nil, is called after each parsed course and once more
+	// when parsing completes successfully. With Concurrency, it is called from
+	// the goroutines parsing faculties, one call at a time, with the totals of
+	// all of them.
+	Progress func(Progress)
+
+	// Charset and Order describe the input. By default, they are detected
+	// from the beginning of the input.
+	Charset Charset
+	Order   Order
+
+	// Stats, if not nil, is filled in with statistics about the parse, even if
+	// it fails.
+	Stats *Stats
+
+	// Concurrency is the maximum amount of faculties parsed in parallel. Values
+	// below 2 mean sequential parsing, which reads the input incrementally;
+	// parallel parsing reads all of it first. The result is the same either
+	// way, as are log messages, which are reported in order.
+	Concurrency int
 }
 
-// Warningf implements Logger.Warningf
-func (g GLogger) Warningf(format string, args ...interface{}) {
-	glog.WarningDepth(3, fmt.Sprintf(format, args...))
+// WarningCategory classifies the warnings logged while parsing.
+type WarningCategory string
+
+// Warning categories reported in Stats.
+const (
+	WarningSkippedCourse  WarningCategory = "skipped_course"
+	WarningHoursAndPoints WarningCategory = "hours_and_points"
+	WarningGroupType      WarningCategory = "group_type"
+	WarningOrphanEvent    WarningCategory = "orphan_event"
+	WarningMissingGroups  WarningCategory = "missing_groups"
+	WarningIgnoredLine    WarningCategory = "ignored_line"
+)
+
+// Stats summarizes a parse, for monitoring.
+type Stats struct {
+	Duration  time.Duration
+	Lines     uint
+	Faculties int
+	Courses   int
+	Groups    int
+
+	// Warnings counts warnings by category. Each skipped course is counted as
+	// a WarningSkippedCourse.
+	Warnings map[WarningCategory]int
 }
 
-// Flush implements Logger.Flush
-func (g GLogger) Flush() {
-	glog.Flush()
+// Progress reports how much of the input ReadFileContext has processed.
+type Progress struct {
+	// Bytes is the amount of bytes read from the input so far. Input is read
+	// ahead in blocks, so this may exceed the amount of bytes actually parsed.
+	Bytes   int64
+	Lines   uint
+	Courses int
 }
 
-// ReadFile reads repyReader, parses it as REPY, and returns a Catalog. If
-// logger is not nil, log messages will be sent to it.
-func ReadFile(repyReader io.Reader, logger Logger) (c *Catalog, err error) {
-	defer func() {
-		if r := recover(); r != nil {
-			err = fmt.Errorf("Failed to read REPY: %v\n%s", r, debug.Stack())
-		}
-	}()
-	defer logger.Flush()
-	d := charmap.CodePage862.NewDecoder()
+// ReadFileContext is like ReadFile, but stops with ctx.Err() (wrapped) if ctx
+// is done between courses, and limits its work according to opts.
+func ReadFileContext(ctx context.Context, repyReader io.Reader, opts ReadOptions) (c *Catalog, err error) {
+	if !opts.Charset.valid() {
+		return nil, errors.Errorf("unknown charset %s", opts.Charset)
+	}
+	if !opts.Order.valid() {
+		return nil, errors.Errorf("unknown order %s", opts.Order)
+	}
+	if opts.Logger != nil {
+		defer opts.Logger.Flush()
+	}
+	input := &countingReader{r: repyReader, limit: opts.MaxInputSize}
+	decoded, charset, order := decodeInput(input, opts.Charset, opts.Order)
+
+	scanner := bufio.NewScanner(decoded)
+	if opts.MaxLineLength > 0 {
+		// The scanner needs room for the line terminator as well.
+		scanner.Buffer(nil, opts.MaxLineLength+2)
+	}
 
 	p := parser{
-		course:  &Course{},
-		scanner: bufio.NewScanner(d.Reader(repyReader)),
-		logger:  logger,
+		ctx:      ctx,
+		course:   &Course{},
+		scanner:  scanner,
+		input:    input,
+		logical:  order == LogicalOrder,
+		logger:   opts.Logger,
+		progress: opts.Progress,
+		warnings: map[WarningCategory]int{},
+	}
+	p.infof("Reading REPY input as %s, %s order", charset, order)
+
+	if opts.Stats != nil {
+		start := time.Now()
+		defer func() {
+			*opts.Stats = p.stats(c)
+			opts.Stats.Duration = time.Since(start)
+		}()
+	}
+
+	if opts.Concurrency > 1 {
+		c, err = p.parseConcurrently(opts.Concurrency)
+	} else {
+		c, err = p.parseFile()
+	}
+	if err != nil {
+		p.log(slog.LevelError, "Failed to parse REPY", slog.String("error", err.Error()))
+		return nil, err
+	}
+	p.reportProgress()
+	return c, nil
+}
+
+// stats returns the Stats of a parse which returned c, which may be nil.
+func (p *parser) stats(c *Catalog) Stats {
+	s := Stats{
+		Lines:    p.line,
+		Courses:  p.courses,
+		Warnings: p.warnings,
 	}
+	if c != nil {
+		s.Faculties = len(*c)
+		for _, f := range *c {
+			for _, course := range f.Courses {
+				s.Groups += len(course.Groups)
+			}
+		}
+	}
+	return s
+}
 
-	return p.parseFile()
+// countingReader counts the bytes read through it, failing with
+// ErrInputTooLarge if more than limit bytes are available (unless limit is
+// zero).
+type countingReader struct {
+	r     io.Reader
+	n     int64
+	limit int64
+}
+
+func (cr *countingReader) Read(b []byte) (int, error) {
+	if cr.limit > 0 && cr.n >= cr.limit {
+		// Only fail if there actually is more input.
+		var probe [1]byte
+		if n, _ := io.ReadFull(cr.r, probe[:]); n == 0 {
+			return 0, io.EOF
+		}
+		return 0, errors.Wrapf(ErrInputTooLarge, "more than %d bytes", cr.limit)
+	}
+	if cr.limit > 0 && int64(len(b)) > cr.limit-cr.n {
+		b = b[:cr.limit-cr.n]
+	}
+	n, err := cr.r.Read(b)
+	cr.n += int64(n)
+	return n, err
 }
 
 func (f Faculty) String() string {
@@ -75,7 +212,7 @@ func (f Faculty) String() string {
 
 func (c Course) String() string {
 	return fmt.Sprintf(
-		"{Course[%d] (%q) AP:%.1f Hours:%v lecturer:%q testDates:%v groups:%v}",
+		"{Course[%s] (%q) AP:%.1f Hours:%v lecturer:%q testDates:%v groups:%v}",
 		c.ID,
 		c.Name,
 		c.AcademicPoints,
@@ -125,7 +262,7 @@ const (
 	sportsBlankLine2 = "|                                                               |"
 )
 
-var idAndNameRegex = regexp.MustCompile(`\| *(.*) +([0-9]{5,6}) +\|`)
+var idAndNameRegex = regexp.MustCompile(`\| *(.*) +([0-9]{5,8}) +\|`)
 
 func (p *parser) parseIDAndName() error {
 	m := idAndNameRegex.FindStringSubmatch(p.text())
@@ -133,33 +270,43 @@ func (p *parser) parseIDAndName() error {
 		return p.errorf("Line %q doesn't match id-and-name regex `%s`", p.text(), idAndNameRegex)
 	}
 
+	id, err := ParseCourseID(m[2])
+	if err != nil {
+		return p.errorf("Invalid course ID: %v", err)
+	}
 	p.course.Name = dedupeSpaces(bidi.Reverse(m[1]))
-	p.course.ID = p.parseUint(m[2])
+	p.course.ID = id
 	p.scan()
 	return nil
 }
 
-func (p *parser) parseUint(s string) uint {
+func (p *parser) parseUint(s string) (uint, error) {
 	result, err := strconv.ParseUint(s, 10, 32)
 	if err != nil {
-		panic(p.errorf("Couldn't ParseUint(%q, 10, 32): %v", s, err))
+		return 0, p.errorfSkip(2, "Couldn't ParseUint(%q, 10, 32): %v", s, err)
 	}
-	return uint(result)
+	return uint(result), nil
 }
 
-func (p *parser) parseFloat(s string) float32 {
+func (p *parser) parseFloat(s string) (float32, error) {
 	result, err := strconv.ParseFloat(s, 32)
 	if err != nil {
-		panic(p.errorf("Couldn't ParseFloat(%q, 32): %v", s, err))
+		return 0, p.errorfSkip(2, "Couldn't ParseFloat(%q, 32): %v", s, err)
 	}
-	return float32(result)
+	return float32(result), nil
 }
 
 func (p *parser) parseTotalHours(totalHours string) error {
 	descriptors := strings.Fields(totalHours)
 	for _, desc := range descriptors {
 		bits := strings.Split(desc, "-")
-		hours := p.parseUint(bits[0])
+		if len(bits) != 2 {
+			return p.errorf("Invalid hour descriptor %q", desc)
+		}
+		hours, err := p.parseUint(bits[0])
+		if err != nil {
+			return errors.Wrapf(err, "invalid hour descriptor %q", desc)
+		}
 		switch bits[1] {
 		case "ה":
 			p.course.WeeklyHours.Lecture = hours
@@ -184,7 +331,11 @@ func (p *parser) parseHoursAndPoints() error {
 		return p.errorf("Line %q doesn't match hoursAndPointsRegex `%s`", p.text(), hoursAndPointsRegex)
 	}
 
-	p.course.AcademicPoints = p.parseFloat(m[1])
+	points, err := p.parseFloat(m[1])
+	if err != nil {
+		return errors.Wrap(err, "invalid academic points")
+	}
+	p.course.AcademicPoints = points
 	if err := p.parseTotalHours(m[2]); err != nil {
 		return errors.Wrapf(err, "couldn't parse total-hours %q in hours-and-points line", m[2])
 	}
@@ -193,14 +344,34 @@ func (p *parser) parseHoursAndPoints() error {
 }
 
 type parser struct {
+	ctx     context.Context
 	scanner *bufio.Scanner
+	input   *countingReader
+	faculty *Faculty
 	course  *Course
 	line    uint
 	groupID uint
 	logger  Logger
 
-	numEOFHits int
-	eof        bool
+	// logical is set for LogicalOrder input, whose lines are converted to
+	// VisualOrder for parsing.
+	logical bool
+	current string
+
+	// lines is the input of parsers without a scanner, which parse a chunk of
+	// the input in parallel to others. partial is set for such parsers,
+	// unless their chunk is at the end of the input.
+	lines   []string
+	partial bool
+
+	progress func(Progress)
+	courses  int
+	warnings map[WarningCategory]int
+
+	// eof is set once the scanner has no more lines, whether due to reaching
+	// the end of input or due to a read error. The latter is kept in err.
+	eof bool
+	err error
 }
 
 func (p *parser) errorfSkip(skip int, format string, a ...interface{}) error {
@@ -215,37 +386,102 @@ func (p *parser) errorf(format string, a ...interface{}) error {
 	return p.errorfSkip(2, format, a...)
 }
 
-func (p *parser) infof(format string, a ...interface{}) {
-	if p.logger != nil {
-		p.logger.Infof("Line %d: %s", p.line, fmt.Sprintf(format, a...))
+// log sends msg to the logger, along with attributes describing the current
+// position in the input.
+func (p *parser) log(level slog.Level, msg string, attrs ...slog.Attr) {
+	if p.logger == nil {
+		return
+	}
+	attrs = append([]slog.Attr{slog.Uint64("line", uint64(p.line))}, attrs...)
+	if p.faculty != nil && p.faculty.Name != "" {
+		attrs = append(attrs, slog.String("faculty", p.faculty.Name))
 	}
+	if p.course != nil && p.course.ID != "" {
+		attrs = append(attrs, slog.String("courseID", string(p.course.ID)))
+	}
+	p.logger.Log(p.ctx, level, msg, attrs...)
+}
+
+func (p *parser) debugf(format string, a ...interface{}) {
+	p.log(slog.LevelDebug, fmt.Sprintf(format, a...))
+}
+
+func (p *parser) infof(format string, a ...interface{}) {
+	p.log(slog.LevelInfo, fmt.Sprintf(format, a...))
 }
-func (p *parser) warningf(format string, a ...interface{}) {
-	if p.logger != nil {
-		p.logger.Warningf("Line %d: %s", p.line, fmt.Sprintf(format, a...))
+
+func (p *parser) warningf(category WarningCategory, format string, a ...interface{}) {
+	if p.warnings != nil {
+		p.warnings[category]++
 	}
+	p.log(slog.LevelWarn, fmt.Sprintf(format, a...), slog.String("category", string(category)))
 }
 
+// scan advances to the next line, returning false if there are no more lines.
+// Once scan has returned false, text will return "".
 func (p *parser) scan() bool {
-	result := p.scanner.Scan()
-	if result {
+	if p.eof {
+		return false
+	}
+	if line, ok := p.readLine(); ok {
 		p.line++
-	} else {
-		if err := p.scanner.Err(); err != nil {
-			panic(err)
+		p.current = line
+		return true
+	}
+	if !p.partial {
+		p.infof("Hit EOF")
+	}
+	p.eof = true
+	p.current = ""
+	return false
+}
+
+// readLine returns the next line of input, in visual order. It returns false
+// if there are no more lines, setting p.err on read errors.
+func (p *parser) readLine() (string, bool) {
+	if p.scanner == nil {
+		if len(p.lines) == 0 {
+			return "", false
 		}
-		p.infof("Hit EOF, numEOFHits is %d. Stack trace:\n%s", p.numEOFHits, string(debug.Stack()))
-		if p.numEOFHits > 10 {
-			panic("Hit EOF too many times")
+		line := p.lines[0]
+		p.lines = p.lines[1:]
+		return line, true
+	}
+	if p.scanner.Scan() {
+		line := p.scanner.Text()
+		if p.logical {
+			line = bidi.Reorder(line)
 		}
-		p.numEOFHits++
-		p.eof = true
+		return line, true
+	}
+	if err := p.scanner.Err(); err == bufio.ErrTooLong {
+		p.err = errors.Wrapf(ErrLineTooLong, "Line %d", p.line+1)
+	} else if err != nil {
+		p.err = errors.Wrapf(err, "failed to read line %d", p.line+1)
+	}
+	return "", false
+}
+
+// courseDone should be called whenever a course has been parsed. It returns
+// an error if parsing should stop.
+func (p *parser) courseDone() error {
+	p.courses++
+	p.reportProgress()
+	return p.ctx.Err()
+}
+
+func (p *parser) reportProgress() {
+	if p.progress != nil {
+		p.progress(Progress{
+			Bytes:   p.input.n,
+			Lines:   p.line,
+			Courses: p.courses,
+		})
 	}
-	return result
 }
 
 func (p *parser) text() string {
-	return p.scanner.Text()
+	return p.current
 }
 
 var (
@@ -282,6 +518,22 @@ func dedupeSpaces(s string) string {
 	return s
 }
 
+func (p *parser) parseDate(year, month, day string) (Date, error) {
+	var d Date
+	var err error
+	if d.Year, err = p.parseUint(year); err != nil {
+		return Date{}, err
+	}
+	if d.Month, err = p.parseUint(month); err != nil {
+		return Date{}, err
+	}
+	if d.Day, err = p.parseUint(day); err != nil {
+		return Date{}, err
+	}
+	d.Year = fixTwoDigitYear(d.Year)
+	return d, nil
+}
+
 func (p *parser) parseCourseHeadInfo() error {
 	var notesBuilder strings.Builder
 
@@ -298,10 +550,9 @@ func (p *parser) parseCourseHeadInfo() error {
 		if separatorLineRegex.MatchString(p.text()) {
 			// skip
 		} else if m := testDateRegex.FindStringSubmatch(p.text()); m != nil {
-			d := Date{
-				fixTwoDigitYear(p.parseUint(m[3])),
-				p.parseUint(m[2]),
-				p.parseUint(m[1]),
+			d, err := p.parseDate(m[3], m[2], m[1])
+			if err != nil {
+				return errors.Wrap(err, "invalid test date")
 			}
 			p.course.TestDates = append(p.course.TestDates, d)
 		} else if m := lecturerInChargeRegex.FindStringSubmatch(p.text()); m != nil {
@@ -345,6 +596,9 @@ func (p *parser) parseFile() (*Catalog, error) {
 
 faculties:
 	for {
+		if err := p.ctx.Err(); err != nil {
+			return nil, errors.Wrap(err, "stopped parsing")
+		}
 		catalog = append(catalog, Faculty{})
 		currentFaculty := &catalog[len(catalog)-1]
 		switch err := p.parseFaculty(currentFaculty); err {
@@ -354,14 +608,23 @@ faculties:
 			catalog = catalog[0 : len(catalog)-1]
 			break faculties
 		default:
+			if p.err != nil {
+				// Parsing errors are likely caused by the read error.
+				return nil, p.err
+			}
 			return nil, errors.Wrap(err, "failed to parse a faculty")
 		}
 	}
 
+	if p.err != nil {
+		return nil, p.err
+	}
+
 	return &catalog, nil
 }
 
 func (p *parser) parseFaculty(faculty *Faculty) error {
+	p.faculty = faculty
 	for strings.TrimSpace(p.text()) == "" {
 		if !p.scan() {
 			return io.EOF
@@ -403,12 +666,15 @@ courses:
 			break courses
 		case nil:
 			faculty.Courses = append(faculty.Courses, *course)
-			// Keep scanning
+			if err := p.courseDone(); err != nil {
+				return errors.Wrap(err, "stopped parsing")
+			}
 		default:
-			p.errorf("failed to scan a course in faculty %s: %v", faculty.Name, err)
-			p.warningf("skipping to next course")
+			p.warningf(WarningSkippedCourse, "failed to scan a course in faculty %s, skipping to next course: %v", faculty.Name, err)
 			for p.text() != courseSep {
-				p.scan()
+				if !p.scan() {
+					return errors.Wrap(err, "reached EOF while skipping a bad course")
+				}
 			}
 		}
 	}
@@ -418,6 +684,10 @@ courses:
 
 const sportsFacultyName = "מקצועות ספורט"
 
+// sportsTeamsCourseName is part of the name of courses for representative
+// teams, whose groups are at SportsLevelTeam.
+const sportsTeamsCourseName = "נבחרות"
+
 var sportsFacultySemesterRegexp = regexp.MustCompile(`\| *([א-ת" ]+) +רטסמס *- *טרופס תועוצקמ *\|`)
 
 func (p *parser) parseSportsFaculty(faculty *Faculty) error {
@@ -448,12 +718,15 @@ courses:
 			break courses
 		case nil:
 			faculty.Courses = append(faculty.Courses, *course)
-			// Keep scanning
+			if err := p.courseDone(); err != nil {
+				return errors.Wrap(err, "stopped parsing")
+			}
 		default:
-			p.errorf("failed to scan a sports course: %v", err)
-			p.warningf("skipping to next course")
+			p.warningf(WarningSkippedCourse, "failed to scan a sports course, skipping to next course: %v", err)
 			for p.text() != sportsCourseSep {
-				p.scan()
+				if !p.scan() {
+					return errors.Wrap(err, "reached EOF while skipping a bad sports course")
+				}
 			}
 		}
 	}
@@ -483,7 +756,7 @@ func (p *parser) parseCourse() (*Course, error) {
 	}
 
 	if err := p.parseHoursAndPoints(); err != nil {
-		p.warningf("Invalid hours and points line: %v", err)
+		p.warningf(WarningHoursAndPoints, "Invalid hours and points line: %v", err)
 		p.scan()
 	}
 
@@ -521,7 +794,7 @@ func (p *parser) parseSportsCourse() (*Course, error) {
 	}
 
 	if err := p.parseHoursAndPoints(); err != nil {
-		p.warningf("Invalid hours and points line in sports course: %v", err)
+		p.warningf(WarningHoursAndPoints, "Invalid hours and points line in sports course: %v", err)
 		p.scan()
 	}
 
@@ -537,7 +810,7 @@ func (p *parser) parseSportsCourse() (*Course, error) {
 		return nil, errors.Wrap(err, "failed to parse groups for course")
 	}
 
-	p.infof("Got all groups for course %d", p.course.ID)
+	p.infof("Got all groups for course %s", p.course.ID)
 
 	return p.course, nil
 }
@@ -550,7 +823,7 @@ func (p *parser) expectLineAndAdvance(s string) error {
 	return nil
 }
 
-func (p *parser) weekDayFromHebrewLetter(letter string) time.Weekday {
+func (p *parser) weekDayFromHebrewLetter(letter string) (time.Weekday, error) {
 	mapping := map[string]time.Weekday{
 		"א": time.Sunday,
 		"ב": time.Monday,
@@ -563,16 +836,44 @@ func (p *parser) weekDayFromHebrewLetter(letter string) time.Weekday {
 
 	result, ok := mapping[letter]
 	if !ok {
-		panic(p.errorf("Invalid weekday letter %q", letter))
+		return 0, p.errorf("Invalid weekday letter %q", letter)
 	}
 
-	return result
+	return result, nil
 }
 
-func (p *parser) timeOfDayFromStrings(hours, minutes string) MinutesSinceMidnight {
-	h := p.parseUint(hours)
-	m := p.parseUint(minutes)
-	return MinutesSinceMidnight(h*60 + m)
+func (p *parser) timeOfDayFromStrings(hours, minutes string) (MinutesSinceMidnight, error) {
+	h, err := p.parseUint(hours)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid hour")
+	}
+	m, err := p.parseUint(minutes)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid minute")
+	}
+	return MinutesSinceMidnight(h*60 + m), nil
+}
+
+// parseEvent parses the common fields of event lines and sports lines, as
+// matched by eventRegexp and sportLineRegexp.
+func (p *parser) parseEvent(m map[string]string) (Event, error) {
+	var ev Event
+	var err error
+
+	if ev.Day, err = p.weekDayFromHebrewLetter(m["weekday"]); err != nil {
+		return Event{}, err
+	}
+	if ev.StartMinute, err = p.timeOfDayFromStrings(m["startHour"], m["startMinute"]); err != nil {
+		return Event{}, errors.Wrap(err, "invalid start time")
+	}
+	if ev.EndMinute, err = p.timeOfDayFromStrings(m["endHour"], m["endMinute"]); err != nil {
+		return Event{}, errors.Wrap(err, "invalid end time")
+	}
+	if ev.Location, err = p.parseLocation(m["location"]); err != nil {
+		return Event{}, errors.Wrap(err, "invalid location")
+	}
+
+	return ev, nil
 }
 
 func (p *parser) groupTypeFromString(s string) (GroupType, error) {
@@ -592,14 +893,17 @@ func (p *parser) groupTypeFromString(s string) (GroupType, error) {
 
 var standardLocationRegexp = regexp.MustCompile(`([א-ת\.]+) ([0-9]+)`)
 
-func (p *parser) parseLocation(s string) string {
+func (p *parser) parseLocation(s string) (string, error) {
 	m := standardLocationRegexp.FindStringSubmatch(s)
 	if len(m) == 0 {
-		return dedupeSpaces(bidi.Reverse(s))
+		return dedupeSpaces(bidi.Reverse(s)), nil
 	}
 	building := dedupeSpaces(bidi.Reverse(m[1]))
-	room := p.parseUint(m[2])
-	return fmt.Sprintf("%s %d", building, room)
+	room, err := p.parseUint(m[2])
+	if err != nil {
+		return "", errors.Wrap(err, "invalid room number")
+	}
+	return fmt.Sprintf("%s %d", building, room), nil
 }
 
 func (p *parser) lastGroup() *Group {
@@ -659,25 +963,24 @@ var eventRegexp = regexp.MustCompile(
 		`*\|`)
 
 // parseEventLine returns true iff it has successfully parsed the current line
-// as an event line.
-func (p *parser) parseEventLine() bool {
+// as an event line. An error is returned if the line looks like an event line,
+// but its fields are invalid.
+func (p *parser) parseEventLine() (bool, error) {
 	m := findStringSubmatchMap(eventRegexp, p.text())
 	if len(m) == 0 {
-		return false
+		return false, nil
 	}
 
-	ev := Event{
-		Day:         p.weekDayFromHebrewLetter(m["weekday"]),
-		StartMinute: p.timeOfDayFromStrings(m["startHour"], m["startMinute"]),
-		EndMinute:   p.timeOfDayFromStrings(m["endHour"], m["endMinute"]),
-		Location:    p.parseLocation(m["location"]),
+	ev, err := p.parseEvent(m)
+	if err != nil {
+		return false, errors.Wrap(err, "invalid event line")
 	}
 
 	if m["groupType"] != "" {
 		groupType, err := p.groupTypeFromString(m["groupType"])
 		if err != nil {
-			p.warningf("Failed to parse group type %q: %v", m["groupType"], err)
-			return false
+			p.warningf(WarningGroupType, "Failed to parse group type %q: %v", m["groupType"], err)
+			return false, nil
 		}
 
 		group := Group{
@@ -687,7 +990,9 @@ func (p *parser) parseEventLine() bool {
 		}
 
 		if m["groupID"] != "" {
-			group.ID = p.parseUint(m["groupID"])
+			if group.ID, err = p.parseUint(m["groupID"]); err != nil {
+				return false, errors.Wrap(err, "invalid group ID")
+			}
 			p.groupID = group.ID + 1
 		} else {
 			group.ID = p.groupID
@@ -701,20 +1006,24 @@ func (p *parser) parseEventLine() bool {
 		group := &p.course.Groups[len(p.course.Groups)-1]
 		group.Events = append(group.Events, ev)
 	} else {
-		p.warningf("Couldn't establish a group, nowhere to add event %q", p.text())
+		p.warningf(WarningOrphanEvent, "Couldn't establish a group, nowhere to add event %q", p.text())
 	}
 
 	p.scan()
-	return true
+	return true, nil
 }
 
 func (p *parser) parseGroups() error {
 	if p.text() != groupSep1 && p.text() != blankLine2 {
-		p.warningf("Expected either %q or %q, got %q; skipping course", groupSep1, blankLine2, p.text())
+		p.warningf(WarningMissingGroups, "Expected either %q or %q, got %q; skipping course", groupSep1, blankLine2, p.text())
 		return nil
 	}
 
 	for {
+		if p.eof {
+			return p.errorf("Unexpected EOF while parsing groups")
+		}
+
 		if p.text() == groupSep1 {
 			p.scan()
 			if err := p.expectLineAndAdvance(groupSep2); err != nil {
@@ -728,12 +1037,14 @@ func (p *parser) parseGroups() error {
 			return nil
 		} else if matchesAny(p.text(), blankLine1, blankLine2) {
 			p.scan()
-		} else if p.parseEventLine() {
+		} else if ok, err := p.parseEventLine(); err != nil {
+			return err
+		} else if ok {
 			// Continue parsing event lines
 		} else if p.parseLecturerLine() {
 			p.scan()
 		} else {
-			p.warningf("Ignored group line %q", p.text())
+			p.warningf(WarningIgnoredLine, "Ignored group line %q", p.text())
 			p.scan()
 		}
 	}
@@ -750,31 +1061,41 @@ var sportLineRegexp = regexp.MustCompile(`\| *` +
 
 func (p *parser) parseSportsGroups() error {
 	if p.text() != sportsBlankLine2 {
-		p.warningf("Expected either %q or %q, got %q; skipping course", groupSep1, blankLine2, p.text())
-		log.Printf("buhbye, line is %q", p.text())
+		p.warningf(WarningMissingGroups, "Expected either %q or %q, got %q; skipping course", groupSep1, blankLine2, p.text())
 		return nil
 	}
 
 	var group Group
 
 	for {
+		if p.eof {
+			return p.errorf("Unexpected EOF while parsing sports groups")
+		}
+
 		if p.text() == sportsBlankLine2 {
 			p.scan()
 		} else if m := findStringSubmatchMap(sportLineRegexp, p.text()); len(m) > 0 {
-			ev := Event{
-				Day:         p.weekDayFromHebrewLetter(m["weekday"]),
-				StartMinute: p.timeOfDayFromStrings(m["startHour"], m["startMinute"]),
-				EndMinute:   p.timeOfDayFromStrings(m["endHour"], m["endMinute"]),
-				Location:    p.parseLocation(m["location"]),
+			ev, err := p.parseEvent(m)
+			if err != nil {
+				return errors.Wrap(err, "invalid sports line")
 			}
 
 			if m["groupID"] != "" {
-				id := p.parseUint(m["groupID"])
+				id, err := p.parseUint(m["groupID"])
+				if err != nil {
+					return errors.Wrap(err, "invalid sports group ID")
+				}
+				description := dedupeSpaces(bidi.Reverse(collapseSpaces(m["description"])))
+				sports := ParseSportsDescription(description)
+				if strings.Contains(p.course.Name, sportsTeamsCourseName) {
+					sports.Level = SportsLevelTeam
+				}
 				p.course.Groups = append(p.course.Groups, Group{
 					ID:          id,
-					Description: dedupeSpaces(bidi.Reverse(collapseSpaces(m["description"]))),
+					Description: description,
 					Type:        Sport,
 					Teachers:    []string{},
+					Sports:      &sports,
 				})
 			}
 
@@ -784,7 +1105,7 @@ func (p *parser) parseSportsGroups() error {
 			p.scan()
 			return nil
 		} else {
-			p.warningf("Ignored sports line %q", p.text())
+			p.warningf(WarningIgnoredLine, "Ignored sports line %q", p.text())
 			p.scan()
 		}
 	}
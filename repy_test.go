@@ -2,12 +2,14 @@ package repy
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -66,8 +68,10 @@ func TestParseLocation(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		got := cp.parseLocation(tc.s)
-		if got != tc.want {
+		got, err := cp.parseLocation(tc.s)
+		if err != nil {
+			t.Errorf("cp.parseLocation(%q) returned error: %v", tc.s, err)
+		} else if got != tc.want {
 			t.Errorf("cp.parseLocation(%q) == %q; want %q", tc.s, got, tc.want)
 		}
 	}
@@ -90,17 +94,29 @@ func getAllREPYs() []string {
 }
 
 func BenchmarkParse(b *testing.B) {
+	modes := []struct {
+		name        string
+		concurrency int
+	}{
+		{"Sequential", 0},
+		{"Concurrent", runtime.GOMAXPROCS(0)},
+	}
+
 	for _, fullPathRepy := range getAllREPYs() {
-		b.Run(filepath.Base(fullPathRepy), func(b *testing.B) {
-			for i := 0; i < b.N; i++ {
-				repyFile, err := os.Open(fullPathRepy)
-				if err != nil {
-					b.Fatalf("Couldn't open %q: %v", fullPathRepy, err)
-				}
+		for _, mode := range modes {
+			b.Run(filepath.Base(fullPathRepy)+"/"+mode.name, func(b *testing.B) {
+				opts := ReadOptions{Logger: GLogger{}, Concurrency: mode.concurrency}
+				for i := 0; i < b.N; i++ {
+					repyFile, err := os.Open(fullPathRepy)
+					if err != nil {
+						b.Fatalf("Couldn't open %q: %v", fullPathRepy, err)
+					}
 
-				ReadFile(repyFile, GLogger{})
-			}
-		})
+					ReadFileContext(context.Background(), repyFile, opts)
+					repyFile.Close()
+				}
+			})
+		}
 	}
 }
 
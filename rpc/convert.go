@@ -0,0 +1,92 @@
+package rpc
+
+import (
+	"time"
+
+	"github.com/lutzky/repy"
+	"github.com/lutzky/repy/publish"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Days are numbered from DAY_SUNDAY, so that DAY_UNSPECIFIED can be zero.
+func dayToProto(d time.Weekday) Day {
+	return Day(d + 1)
+}
+
+func dayFromProto(d Day) (time.Weekday, bool) {
+	if d < Day_DAY_SUNDAY || d > Day_DAY_SATURDAY {
+		return 0, false
+	}
+	return time.Weekday(d - 1), true
+}
+
+var groupTypes = map[repy.GroupType]GroupType{
+	repy.Lecture:  GroupType_GROUP_TYPE_LECTURE,
+	repy.Tutorial: GroupType_GROUP_TYPE_TUTORIAL,
+	repy.Lab:      GroupType_GROUP_TYPE_LAB,
+	repy.Sport:    GroupType_GROUP_TYPE_SPORT,
+}
+
+func versionToProto(e publish.IndexEntry) *Version {
+	return &Version{
+		Sha1Sum:   e.Sha1Sum,
+		TimeStamp: timestamppb.New(e.TimeStamp),
+		Semester:  e.Semester,
+	}
+}
+
+// courseToProto converts c, which is listed in faculty f.
+func courseToProto(f *repy.Faculty, c *repy.Course) *Course {
+	result := &Course{
+		Id:               string(c.ID),
+		Name:             c.Name,
+		AcademicPoints:   c.AcademicPoints,
+		LecturerInCharge: c.LecturerInCharge,
+		WeeklyHours: &WeeklyHours{
+			Lecture:  uint32(c.WeeklyHours.Lecture),
+			Tutorial: uint32(c.WeeklyHours.Tutorial),
+			Lab:      uint32(c.WeeklyHours.Lab),
+			Project:  uint32(c.WeeklyHours.Project),
+		},
+		Notes:    c.Notes,
+		Faculty:  f.Name,
+		Semester: f.Semester,
+	}
+	for _, d := range c.TestDates {
+		result.TestDates = append(result.TestDates, &Date{
+			Year:  uint32(d.Year),
+			Month: uint32(d.Month),
+			Day:   uint32(d.Day),
+		})
+	}
+	for _, g := range c.Groups {
+		result.Groups = append(result.Groups, groupToProto(g))
+	}
+	return result
+}
+
+func groupToProto(g repy.Group) *Group {
+	result := &Group{
+		Id:          uint32(g.ID),
+		Teachers:    g.Teachers,
+		Type:        groupTypes[g.Type],
+		Description: g.Description,
+	}
+	for _, ev := range g.Events {
+		result.Events = append(result.Events, &Event{
+			Day:         dayToProto(ev.Day),
+			Location:    ev.Location,
+			StartMinute: uint32(ev.StartMinute),
+			EndMinute:   uint32(ev.EndMinute),
+		})
+	}
+	if g.Sports != nil {
+		result.Sports = &SportsInfo{
+			Activity:     g.Sports.Activity,
+			Gender:       string(g.Sports.Gender),
+			Level:        string(g.Sports.Level),
+			MedicineOnly: g.Sports.MedicineOnly,
+		}
+	}
+	return result
+}
@@ -0,0 +1,324 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: repy.proto
+
+package rpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	CatalogService_GetCourse_FullMethodName     = "/repy.v1.CatalogService/GetCourse"
+	CatalogService_ListCourses_FullMethodName   = "/repy.v1.CatalogService/ListCourses"
+	CatalogService_ListSemesters_FullMethodName = "/repy.v1.CatalogService/ListSemesters"
+	CatalogService_StreamCourses_FullMethodName = "/repy.v1.CatalogService/StreamCourses"
+	CatalogService_WatchVersions_FullMethodName = "/repy.v1.CatalogService/WatchVersions"
+)
+
+// CatalogServiceClient is the client API for CatalogService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CatalogServiceClient interface {
+	// GetCourse returns the first appearance of a course.
+	GetCourse(ctx context.Context, in *GetCourseRequest, opts ...grpc.CallOption) (*Course, error)
+	// ListCourses returns the courses matching a filter, a page at a time.
+	ListCourses(ctx context.Context, in *ListCoursesRequest, opts ...grpc.CallOption) (*ListCoursesResponse, error)
+	// ListSemesters returns the semesters of all published versions.
+	ListSemesters(ctx context.Context, in *ListSemestersRequest, opts ...grpc.CallOption) (*ListSemestersResponse, error)
+	// StreamCourses streams all courses matching a filter.
+	StreamCourses(ctx context.Context, in *StreamCoursesRequest, opts ...grpc.CallOption) (CatalogService_StreamCoursesClient, error)
+	// WatchVersions streams the latest version, and then every newer version as
+	// it is published.
+	WatchVersions(ctx context.Context, in *WatchVersionsRequest, opts ...grpc.CallOption) (CatalogService_WatchVersionsClient, error)
+}
+
+type catalogServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCatalogServiceClient(cc grpc.ClientConnInterface) CatalogServiceClient {
+	return &catalogServiceClient{cc}
+}
+
+func (c *catalogServiceClient) GetCourse(ctx context.Context, in *GetCourseRequest, opts ...grpc.CallOption) (*Course, error) {
+	out := new(Course)
+	err := c.cc.Invoke(ctx, CatalogService_GetCourse_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ListCourses(ctx context.Context, in *ListCoursesRequest, opts ...grpc.CallOption) (*ListCoursesResponse, error) {
+	out := new(ListCoursesResponse)
+	err := c.cc.Invoke(ctx, CatalogService_ListCourses_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ListSemesters(ctx context.Context, in *ListSemestersRequest, opts ...grpc.CallOption) (*ListSemestersResponse, error) {
+	out := new(ListSemestersResponse)
+	err := c.cc.Invoke(ctx, CatalogService_ListSemesters_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) StreamCourses(ctx context.Context, in *StreamCoursesRequest, opts ...grpc.CallOption) (CatalogService_StreamCoursesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CatalogService_ServiceDesc.Streams[0], CatalogService_StreamCourses_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &catalogServiceStreamCoursesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CatalogService_StreamCoursesClient interface {
+	Recv() (*Course, error)
+	grpc.ClientStream
+}
+
+type catalogServiceStreamCoursesClient struct {
+	grpc.ClientStream
+}
+
+func (x *catalogServiceStreamCoursesClient) Recv() (*Course, error) {
+	m := new(Course)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *catalogServiceClient) WatchVersions(ctx context.Context, in *WatchVersionsRequest, opts ...grpc.CallOption) (CatalogService_WatchVersionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CatalogService_ServiceDesc.Streams[1], CatalogService_WatchVersions_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &catalogServiceWatchVersionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CatalogService_WatchVersionsClient interface {
+	Recv() (*Version, error)
+	grpc.ClientStream
+}
+
+type catalogServiceWatchVersionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *catalogServiceWatchVersionsClient) Recv() (*Version, error) {
+	m := new(Version)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// CatalogServiceServer is the server API for CatalogService service.
+// All implementations must embed UnimplementedCatalogServiceServer
+// for forward compatibility
+type CatalogServiceServer interface {
+	// GetCourse returns the first appearance of a course.
+	GetCourse(context.Context, *GetCourseRequest) (*Course, error)
+	// ListCourses returns the courses matching a filter, a page at a time.
+	ListCourses(context.Context, *ListCoursesRequest) (*ListCoursesResponse, error)
+	// ListSemesters returns the semesters of all published versions.
+	ListSemesters(context.Context, *ListSemestersRequest) (*ListSemestersResponse, error)
+	// StreamCourses streams all courses matching a filter.
+	StreamCourses(*StreamCoursesRequest, CatalogService_StreamCoursesServer) error
+	// WatchVersions streams the latest version, and then every newer version as
+	// it is published.
+	WatchVersions(*WatchVersionsRequest, CatalogService_WatchVersionsServer) error
+	mustEmbedUnimplementedCatalogServiceServer()
+}
+
+// UnimplementedCatalogServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedCatalogServiceServer struct {
+}
+
+func (UnimplementedCatalogServiceServer) GetCourse(context.Context, *GetCourseRequest) (*Course, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCourse not implemented")
+}
+func (UnimplementedCatalogServiceServer) ListCourses(context.Context, *ListCoursesRequest) (*ListCoursesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCourses not implemented")
+}
+func (UnimplementedCatalogServiceServer) ListSemesters(context.Context, *ListSemestersRequest) (*ListSemestersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSemesters not implemented")
+}
+func (UnimplementedCatalogServiceServer) StreamCourses(*StreamCoursesRequest, CatalogService_StreamCoursesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamCourses not implemented")
+}
+func (UnimplementedCatalogServiceServer) WatchVersions(*WatchVersionsRequest, CatalogService_WatchVersionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchVersions not implemented")
+}
+func (UnimplementedCatalogServiceServer) mustEmbedUnimplementedCatalogServiceServer() {}
+
+// UnsafeCatalogServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CatalogServiceServer will
+// result in compilation errors.
+type UnsafeCatalogServiceServer interface {
+	mustEmbedUnimplementedCatalogServiceServer()
+}
+
+func RegisterCatalogServiceServer(s grpc.ServiceRegistrar, srv CatalogServiceServer) {
+	s.RegisterService(&CatalogService_ServiceDesc, srv)
+}
+
+func _CatalogService_GetCourse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCourseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).GetCourse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_GetCourse_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).GetCourse(ctx, req.(*GetCourseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ListCourses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCoursesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ListCourses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_ListCourses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ListCourses(ctx, req.(*ListCoursesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ListSemesters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSemestersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ListSemesters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_ListSemesters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ListSemesters(ctx, req.(*ListSemestersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_StreamCourses_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamCoursesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CatalogServiceServer).StreamCourses(m, &catalogServiceStreamCoursesServer{stream})
+}
+
+type CatalogService_StreamCoursesServer interface {
+	Send(*Course) error
+	grpc.ServerStream
+}
+
+type catalogServiceStreamCoursesServer struct {
+	grpc.ServerStream
+}
+
+func (x *catalogServiceStreamCoursesServer) Send(m *Course) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CatalogService_WatchVersions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchVersionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CatalogServiceServer).WatchVersions(m, &catalogServiceWatchVersionsServer{stream})
+}
+
+type CatalogService_WatchVersionsServer interface {
+	Send(*Version) error
+	grpc.ServerStream
+}
+
+type catalogServiceWatchVersionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *catalogServiceWatchVersionsServer) Send(m *Version) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// CatalogService_ServiceDesc is the grpc.ServiceDesc for CatalogService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CatalogService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "repy.v1.CatalogService",
+	HandlerType: (*CatalogServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetCourse",
+			Handler:    _CatalogService_GetCourse_Handler,
+		},
+		{
+			MethodName: "ListCourses",
+			Handler:    _CatalogService_ListCourses_Handler,
+		},
+		{
+			MethodName: "ListSemesters",
+			Handler:    _CatalogService_ListSemesters_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamCourses",
+			Handler:       _CatalogService_StreamCourses_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchVersions",
+			Handler:       _CatalogService_WatchVersions_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "repy.proto",
+}
@@ -0,0 +1,269 @@
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative repy.proto
+
+// Package rpc serves the catalogs published with package publish over gRPC,
+// as defined in repy.proto.
+package rpc
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lutzky/repy"
+	"github.com/lutzky/repy/publish"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Page sizes of ListCourses.
+const (
+	DefaultPageSize = 100
+	MaxPageSize     = 1000
+)
+
+// DefaultPollInterval is the default for Server.PollInterval.
+const DefaultPollInterval = time.Minute
+
+// Server implements CatalogServiceServer over the catalogs in a publish.Store.
+type Server struct {
+	UnimplementedCatalogServiceServer
+
+	catalogs *publish.Reader
+
+	// PollInterval is how often WatchVersions checks for new versions. Zero or
+	// negative means DefaultPollInterval.
+	PollInterval time.Duration
+}
+
+// NewServer returns a Server for the catalogs in store.
+func NewServer(store publish.Store) *Server {
+	return &Server{
+		catalogs:     publish.NewReader(store),
+		PollInterval: DefaultPollInterval,
+	}
+}
+
+// statusError converts err to a gRPC status error.
+func statusError(err error) error {
+	switch {
+	case errors.Is(err, publish.ErrNoSuchVersion), errors.Is(err, publish.ErrNotExist):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func (s *Server) catalog(ctx context.Context, sel *VersionSelector) (*repy.Catalog, *publish.IndexEntry, error) {
+	c, entry, err := s.catalogs.Catalog(ctx, sel.GetVersion(), sel.GetSemester())
+	if err != nil {
+		return nil, nil, statusError(err)
+	}
+	return c, entry, nil
+}
+
+func courseFilter(f *CourseFilter) (repy.CourseFilter, error) {
+	result := repy.CourseFilter{
+		Faculty:  f.GetFaculty(),
+		Lecturer: f.GetLecturer(),
+	}
+	if f.GetId() != "" {
+		id, err := repy.ParseCourseID(f.GetId())
+		if err != nil {
+			return result, status.Error(codes.InvalidArgument, err.Error())
+		}
+		result.ID = id
+	}
+	if f.GetDay() != Day_DAY_UNSPECIFIED {
+		day, ok := dayFromProto(f.GetDay())
+		if !ok {
+			return result, status.Errorf(codes.InvalidArgument, "invalid day %v", f.GetDay())
+		}
+		result.Day = &day
+	}
+	return result, nil
+}
+
+// forEachCourse calls fn with the courses in c which match cf, in order, until
+// it returns false.
+func forEachCourse(c *repy.Catalog, cf repy.CourseFilter, fn func(*repy.Faculty, *repy.Course) bool) {
+	for i := range *c {
+		f := &(*c)[i]
+		for j := range f.Courses {
+			if cf.Match(f, &f.Courses[j]) && !fn(f, &f.Courses[j]) {
+				return
+			}
+		}
+	}
+}
+
+// GetCourse implements CatalogServiceServer.
+func (s *Server) GetCourse(ctx context.Context, req *GetCourseRequest) (*Course, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing course ID")
+	}
+	filter, err := courseFilter(&CourseFilter{Id: req.GetId()})
+	if err != nil {
+		return nil, err
+	}
+	c, _, err := s.catalog(ctx, req.GetSelector())
+	if err != nil {
+		return nil, err
+	}
+
+	var result *Course
+	forEachCourse(c, filter, func(f *repy.Faculty, course *repy.Course) bool {
+		result = courseToProto(f, course)
+		return false
+	})
+	if result == nil {
+		return nil, status.Errorf(codes.NotFound, "no course %s", filter.ID)
+	}
+	return result, nil
+}
+
+// pageToken identifies the position of a page within a version.
+type pageToken struct {
+	version string
+	offset  int
+}
+
+func (t pageToken) String() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(t.version + ":" + strconv.Itoa(t.offset)))
+}
+
+func parsePageToken(s string) (pageToken, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return pageToken{}, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+	parts := strings.SplitN(string(b), ":", 2)
+	if len(parts) != 2 {
+		return pageToken{}, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+	offset, err := strconv.Atoi(parts[1])
+	if err != nil || offset < 0 {
+		return pageToken{}, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+	return pageToken{version: parts[0], offset: offset}, nil
+}
+
+// ListCourses implements CatalogServiceServer.
+func (s *Server) ListCourses(ctx context.Context, req *ListCoursesRequest) (*ListCoursesResponse, error) {
+	pageSize := int(req.GetPageSize())
+	switch {
+	case pageSize < 0 || pageSize > MaxPageSize:
+		return nil, status.Errorf(codes.InvalidArgument, "page size must be between 0 and %d", MaxPageSize)
+	case pageSize == 0:
+		pageSize = DefaultPageSize
+	}
+	filter, err := courseFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	sel := req.GetSelector()
+	var token pageToken
+	if req.GetPageToken() != "" {
+		if token, err = parsePageToken(req.GetPageToken()); err != nil {
+			return nil, err
+		}
+		sel = &VersionSelector{Version: token.version}
+	}
+	c, entry, err := s.catalog(ctx, sel)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ListCoursesResponse{Version: entry.Sha1Sum}
+	i := 0
+	forEachCourse(c, filter, func(f *repy.Faculty, course *repy.Course) bool {
+		if i >= token.offset+pageSize {
+			resp.NextPageToken = pageToken{version: entry.Sha1Sum, offset: i}.String()
+			return false
+		}
+		if i >= token.offset {
+			resp.Courses = append(resp.Courses, courseToProto(f, course))
+		}
+		i++
+		return true
+	})
+	return resp, nil
+}
+
+// ListSemesters implements CatalogServiceServer.
+func (s *Server) ListSemesters(ctx context.Context, req *ListSemestersRequest) (*ListSemestersResponse, error) {
+	versions, err := s.catalogs.Versions(ctx, "")
+	if err != nil && !errors.Is(err, publish.ErrNotExist) {
+		return nil, statusError(err)
+	}
+
+	resp := &ListSemestersResponse{}
+	bySemester := map[string]*Semester{}
+	for _, v := range versions {
+		sem, ok := bySemester[v.Semester]
+		if !ok {
+			sem = &Semester{Name: v.Semester}
+			bySemester[v.Semester] = sem
+			resp.Semesters = append(resp.Semesters, sem)
+		}
+		sem.Versions = append(sem.Versions, versionToProto(v))
+	}
+	return resp, nil
+}
+
+// StreamCourses implements CatalogServiceServer.
+func (s *Server) StreamCourses(req *StreamCoursesRequest, stream CatalogService_StreamCoursesServer) error {
+	filter, err := courseFilter(req.GetFilter())
+	if err != nil {
+		return err
+	}
+	c, _, err := s.catalog(stream.Context(), req.GetSelector())
+	if err != nil {
+		return err
+	}
+
+	forEachCourse(c, filter, func(f *repy.Faculty, course *repy.Course) bool {
+		err = stream.Send(courseToProto(f, course))
+		return err == nil
+	})
+	return err
+}
+
+func (s *Server) pollInterval() time.Duration {
+	if s.PollInterval <= 0 {
+		return DefaultPollInterval
+	}
+	return s.PollInterval
+}
+
+// WatchVersions implements CatalogServiceServer. Until a version is published,
+// nothing is sent.
+func (s *Server) WatchVersions(req *WatchVersionsRequest, stream CatalogService_WatchVersionsServer) error {
+	ctx := stream.Context()
+	ticker := time.NewTicker(s.pollInterval())
+	defer ticker.Stop()
+
+	var last string
+	for {
+		versions, err := s.catalogs.Versions(ctx, req.GetSemester())
+		if err != nil && !errors.Is(err, publish.ErrNotExist) {
+			return statusError(err)
+		}
+		if len(versions) > 0 && versions[0].Sha1Sum != last {
+			if err := stream.Send(versionToProto(versions[0])); err != nil {
+				return err
+			}
+			last = versions[0].Sha1Sum
+		}
+
+		select {
+		case <-ctx.Done():
+			return statusError(ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
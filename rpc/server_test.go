@@ -0,0 +1,292 @@
+package rpc
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lutzky/repy/publish"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+type testEnv struct {
+	store  publish.Store
+	client CatalogServiceClient
+	now    time.Time
+}
+
+// publish publishes a REPY file from testdata, an hour after the previous one,
+// and returns its SHA1 sum.
+func (env *testEnv) publish(t *testing.T, name string) string {
+	t.Helper()
+	data, err := ioutil.ReadFile("../testdata/" + name)
+	if err != nil {
+		t.Fatalf("Couldn't read %q: %v", name, err)
+	}
+	env.now = env.now.Add(time.Hour)
+	now := env.now
+	result, err := publish.Publish(context.Background(), env.store, data, publish.Options{Now: func() time.Time { return now }})
+	if err != nil {
+		t.Fatalf("Publish(%q) failed: %v", name, err)
+	}
+	return result.SHA1Sum
+}
+
+// newTestEnv starts a Server over an empty store, connected in-process.
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	env := &testEnv{
+		store: publish.Dir(t.TempDir()),
+		now:   time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	s := NewServer(env.store)
+	s.PollInterval = 10 * time.Millisecond
+	server := grpc.NewServer()
+	RegisterCatalogServiceServer(server, s)
+
+	lis := bufconn.Listen(1 << 20)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	env.client = NewCatalogServiceClient(conn)
+	return env
+}
+
+func TestGetCourse(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	if _, err := env.client.GetCourse(ctx, &GetCourseRequest{Id: "134058"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetCourse without published versions returned %v; want NotFound", err)
+	}
+
+	env.publish(t, "course_biology_1.repy")
+	env.publish(t, "course_sport_sample.repy")
+
+	got, err := env.client.GetCourse(ctx, &GetCourseRequest{
+		Selector: &VersionSelector{Semester: `חורף תשע"ו`},
+		Id:       "134058",
+	})
+	if err != nil {
+		t.Fatalf("GetCourse failed: %v", err)
+	}
+	want := &Course{
+		Id:               "134058",
+		Name:             "ביולוגיה 1",
+		AcademicPoints:   3,
+		LecturerInCharge: `ד"ר ד.מאירי`,
+		Faculty:          "פקולטה שקרית",
+		Semester:         `חורף תשע"ו`,
+	}
+	opts := []cmp.Option{
+		protocmp.Transform(),
+		protocmp.IgnoreFields(&Course{}, "weekly_hours", "test_dates", "groups", "notes"),
+	}
+	if d := cmp.Diff(want, got, opts...); d != "" {
+		t.Errorf("GetCourse diff -want +got:\n%s", d)
+	}
+	if g := got.Groups[0]; g.Type != GroupType_GROUP_TYPE_LECTURE || g.Events[0].Day != Day_DAY_SUNDAY {
+		t.Errorf("First group is %v; want a lecture on Sunday", g)
+	}
+
+	sports, err := env.client.GetCourse(ctx, &GetCourseRequest{Id: "394902"})
+	if err != nil {
+		t.Fatalf("GetCourse failed: %v", err)
+	}
+	if sports.Groups[0].Sports.GetActivity() == "" {
+		t.Errorf("Sports group has no activity: %v", sports.Groups[0])
+	}
+
+	errorCases := []struct {
+		req  *GetCourseRequest
+		want codes.Code
+	}{
+		{&GetCourseRequest{}, codes.InvalidArgument},
+		{&GetCourseRequest{Id: "not an ID"}, codes.InvalidArgument},
+		{&GetCourseRequest{Id: "134058"}, codes.NotFound},
+		{&GetCourseRequest{Id: "134058", Selector: &VersionSelector{Version: "nonexistent"}}, codes.NotFound},
+	}
+	for _, ec := range errorCases {
+		if _, err := env.client.GetCourse(ctx, ec.req); status.Code(err) != ec.want {
+			t.Errorf("GetCourse(%v) returned %v; want %v", ec.req, err, ec.want)
+		}
+	}
+}
+
+func TestListAndStreamCourses(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	sum := env.publish(t, "spring_2019_v1.repy")
+
+	filter := &CourseFilter{Day: Day_DAY_FRIDAY}
+
+	stream, err := env.client.StreamCourses(ctx, &StreamCoursesRequest{Filter: filter})
+	if err != nil {
+		t.Fatalf("StreamCourses failed: %v", err)
+	}
+	var streamed []string
+	for {
+		c, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("StreamCourses failed: %v", err)
+		}
+		streamed = append(streamed, c.Id)
+	}
+	if len(streamed) < 3 {
+		t.Fatalf("Only %d courses have events on Friday; want enough for several pages", len(streamed))
+	}
+
+	// A newer version published while paging doesn't affect later pages.
+	var listed []string
+	req := &ListCoursesRequest{Filter: filter, PageSize: 2}
+	for pages := 0; ; pages++ {
+		resp, err := env.client.ListCourses(ctx, req)
+		if err != nil {
+			t.Fatalf("ListCourses failed: %v", err)
+		}
+		if resp.Version != sum {
+			t.Fatalf("ListCourses returned courses from version %q; want %q", resp.Version, sum)
+		}
+		if len(resp.Courses) > 2 {
+			t.Fatalf("ListCourses returned %d courses; want at most 2", len(resp.Courses))
+		}
+		for _, c := range resp.Courses {
+			listed = append(listed, c.Id)
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		if pages == 0 {
+			env.publish(t, "course_biology_1.repy")
+		}
+		req.PageToken = resp.NextPageToken
+	}
+	if d := cmp.Diff(streamed, listed); d != "" {
+		t.Errorf("Listed courses differ from streamed ones, diff -streamed +listed:\n%s", d)
+	}
+
+	errorCases := []*ListCoursesRequest{
+		{PageSize: -1},
+		{PageSize: MaxPageSize + 1},
+		{PageToken: "!!!"},
+		{Filter: &CourseFilter{Day: Day(8)}},
+	}
+	for _, req := range errorCases {
+		if _, err := env.client.ListCourses(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("ListCourses(%v) returned %v; want InvalidArgument", req, err)
+		}
+	}
+}
+
+func TestListSemesters(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	resp, err := env.client.ListSemesters(ctx, &ListSemestersRequest{})
+	if err != nil || len(resp.Semesters) != 0 {
+		t.Errorf("ListSemesters without published versions returned %v, %v; want no semesters", resp, err)
+	}
+
+	first := env.publish(t, "course_biology_1.repy")
+	second := env.publish(t, "course_sport_sample.repy")
+	third := env.publish(t, "course_statistics.repy")
+
+	resp, err = env.client.ListSemesters(ctx, &ListSemestersRequest{})
+	if err != nil {
+		t.Fatalf("ListSemesters failed: %v", err)
+	}
+	type semester struct {
+		Name string
+		Sums []string
+	}
+	var got []semester
+	for _, s := range resp.Semesters {
+		sem := semester{Name: s.Name}
+		for _, v := range s.Versions {
+			sem.Sums = append(sem.Sums, v.Sha1Sum)
+		}
+		got = append(got, sem)
+	}
+	want := []semester{
+		{`חורף תשע"ו`, []string{third, first}},
+		{`אביב תשע"ח`, []string{second}},
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("ListSemesters diff -want +got:\n%s", d)
+	}
+}
+
+func TestWatchVersions(t *testing.T) {
+	env := newTestEnv(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	first := env.publish(t, "course_biology_1.repy")
+
+	stream, err := env.client.WatchVersions(ctx, &WatchVersionsRequest{})
+	if err != nil {
+		t.Fatalf("WatchVersions failed: %v", err)
+	}
+	recv := func() string {
+		t.Helper()
+		v, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("WatchVersions failed: %v", err)
+		}
+		return v.Sha1Sum
+	}
+
+	if got := recv(); got != first {
+		t.Errorf("First version is %q; want %q", got, first)
+	}
+	second := env.publish(t, "course_sport_sample.repy")
+	if got := recv(); got != second {
+		t.Errorf("Next version is %q; want %q", got, second)
+	}
+
+	cancel()
+	if _, err := stream.Recv(); status.Code(err) != codes.Canceled {
+		t.Errorf("WatchVersions returned %v after cancellation; want Canceled", err)
+	}
+}
+
+func TestPollInterval(t *testing.T) {
+	testCases := []struct {
+		pollInterval time.Duration
+		want         time.Duration
+	}{
+		{0, DefaultPollInterval},
+		{-time.Second, DefaultPollInterval},
+		{5 * time.Second, 5 * time.Second},
+	}
+
+	for _, tc := range testCases {
+		s := Server{PollInterval: tc.pollInterval}
+		if got := s.pollInterval(); got != tc.want {
+			t.Errorf("pollInterval() with PollInterval %v = %v; want %v", tc.pollInterval, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package repy
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// GroupRef identifies a group of a course.
+type GroupRef struct {
+	CourseID CourseID `json:"courseId" yaml:"courseId"`
+	GroupID  uint     `json:"groupId" yaml:"groupId"`
+}
+
+// Selection is a set of chosen groups, such as a tentative weekly schedule.
+type Selection []GroupRef
+
+// Contains returns whether ref is in s.
+func (s Selection) Contains(ref GroupRef) bool {
+	for _, r := range s {
+		if r == ref {
+			return true
+		}
+	}
+	return false
+}
+
+// Toggle adds ref to s, or removes it if it is already there. It returns
+// whether ref is now in s.
+func (s *Selection) Toggle(ref GroupRef) bool {
+	for i, r := range *s {
+		if r == ref {
+			*s = append((*s)[:i], (*s)[i+1:]...)
+			return false
+		}
+	}
+	*s = append(*s, ref)
+	return true
+}
+
+// ReadSelection reads a selection in JSON format, as written by
+// WriteSelection.
+func ReadSelection(r io.Reader) (Selection, error) {
+	var s Selection
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, errors.Wrap(err, "failed to decode selection")
+	}
+	return s, nil
+}
+
+// WriteSelection writes s in JSON format.
+func WriteSelection(w io.Writer, s Selection) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if s == nil {
+		s = Selection{}
+	}
+	return errors.Wrap(enc.Encode(s), "failed to encode selection")
+}
+
+// Course returns the first appearance of the course with the given ID in c,
+// or nil if there isn't one.
+func (c *Catalog) Course(id CourseID) *Course {
+	for i := range *c {
+		f := &(*c)[i]
+		for j := range f.Courses {
+			if f.Courses[j].ID == id {
+				return &f.Courses[j]
+			}
+		}
+	}
+	return nil
+}
+
+// ScheduledEvent is an event of a selected group.
+type ScheduledEvent struct {
+	Course *Course
+	Group  *Group
+	Event  Event
+}
+
+// Ref returns the reference to the group of e.
+func (e ScheduledEvent) Ref() GroupRef {
+	return GroupRef{CourseID: e.Course.ID, GroupID: e.Group.ID}
+}
+
+// Resolve returns the events of the groups in s, sorted by time, along with
+// the references in s to groups which aren't in c.
+func (c *Catalog) Resolve(s Selection) (events []ScheduledEvent, missing []GroupRef) {
+	for _, ref := range s {
+		course := c.Course(ref.CourseID)
+		group := course.Group(ref.GroupID)
+		if group == nil {
+			missing = append(missing, ref)
+			continue
+		}
+		for _, ev := range group.Events {
+			events = append(events, ScheduledEvent{Course: course, Group: group, Event: ev})
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Event.Interval().Before(events[j].Event.Interval())
+	})
+	return events, missing
+}
+
+// Overlaps returns whether e and other take place at the same time.
+func (e Event) Overlaps(other Event) bool {
+	return e.Interval().Overlaps(other.Interval())
+}
+
+// Clash is a pair of overlapping events of different groups.
+type Clash struct {
+	First, Second ScheduledEvent
+}
+
+// Clashes returns the pairs of overlapping events, of different groups, among
+// events.
+func Clashes(events []ScheduledEvent) []Clash {
+	var result []Clash
+	for i := range events {
+		for j := i + 1; j < len(events); j++ {
+			if events[i].Ref() != events[j].Ref() && events[i].Event.Overlaps(events[j].Event) {
+				result = append(result, Clash{First: events[i], Second: events[j]})
+			}
+		}
+	}
+	return result
+}
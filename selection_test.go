@@ -0,0 +1,93 @@
+package repy
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSelectionToggle(t *testing.T) {
+	var s Selection
+	a := GroupRef{"234114", 10}
+	b := GroupRef{"234114", 11}
+
+	if !s.Toggle(a) || !s.Toggle(b) {
+		t.Fatalf("Toggle of new refs returned false")
+	}
+	if !s.Contains(a) || !s.Contains(b) {
+		t.Errorf("Selection %v doesn't contain toggled refs", s)
+	}
+	if s.Toggle(a) {
+		t.Errorf("Toggle of existing ref returned true")
+	}
+	if d := cmp.Diff(Selection{b}, s); d != "" {
+		t.Errorf("Selection diff -want +got:\n%s", d)
+	}
+}
+
+func TestSelectionReadWrite(t *testing.T) {
+	s := Selection{{"234114", 10}, {"014003", 11}}
+	var buf bytes.Buffer
+	if err := WriteSelection(&buf, s); err != nil {
+		t.Fatalf("WriteSelection failed: %v", err)
+	}
+	got, err := ReadSelection(&buf)
+	if err != nil {
+		t.Fatalf("ReadSelection failed: %v", err)
+	}
+	if d := cmp.Diff(s, got); d != "" {
+		t.Errorf("Selection diff -want +got:\n%s", d)
+	}
+
+	if _, err := ReadSelection(bytes.NewBufferString(`{"courseId": 1}`)); err == nil {
+		t.Errorf("ReadSelection of an object succeeded; want error")
+	}
+}
+
+func TestResolveAndClashes(t *testing.T) {
+	event := func(day time.Weekday, start, end MinutesSinceMidnight) Event {
+		return Event{Day: day, StartMinute: start, EndMinute: end}
+	}
+	catalog := Catalog{{Courses: []Course{
+		{ID: "234114", Groups: []Group{
+			{ID: 10, Events: []Event{event(time.Sunday, 600, 720), event(time.Tuesday, 600, 660)}},
+			{ID: 11, Events: []Event{event(time.Monday, 600, 720)}},
+		}},
+		{ID: "104031", Groups: []Group{
+			{ID: 10, Events: []Event{event(time.Sunday, 690, 780)}},
+			{ID: 11, Events: []Event{event(time.Sunday, 720, 780)}},
+		}},
+	}}}
+
+	s := Selection{{"234114", 10}, {"104031", 10}, {"104031", 11}, {"234114", 12}, {"999999", 10}}
+	events, missing := catalog.Resolve(s)
+
+	if d := cmp.Diff([]GroupRef{{"234114", 12}, {"999999", 10}}, missing); d != "" {
+		t.Errorf("Missing refs diff -want +got:\n%s", d)
+	}
+
+	var gotRefs []GroupRef
+	for _, ev := range events {
+		gotRefs = append(gotRefs, ev.Ref())
+	}
+	wantRefs := []GroupRef{{"234114", 10}, {"104031", 10}, {"104031", 11}, {"234114", 10}}
+	if d := cmp.Diff(wantRefs, gotRefs); d != "" {
+		t.Errorf("Resolved events diff -want +got:\n%s", d)
+	}
+
+	var gotClashes [][2]GroupRef
+	for _, c := range Clashes(events) {
+		gotClashes = append(gotClashes, [2]GroupRef{c.First.Ref(), c.Second.Ref()})
+	}
+	// 104031 groups 10 and 11 overlap, but 234114 group 10 ends as group 11
+	// starts.
+	wantClashes := [][2]GroupRef{
+		{{"234114", 10}, {"104031", 10}},
+		{{"104031", 10}, {"104031", 11}},
+	}
+	if d := cmp.Diff(wantClashes, gotClashes); d != "" {
+		t.Errorf("Clashes diff -want +got:\n%s", d)
+	}
+}
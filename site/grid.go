@@ -0,0 +1,73 @@
+package site
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lutzky/repy"
+)
+
+// gridStep is the length, in minutes, of each row of the weekly grid.
+const gridStep = 30
+
+var gridDays = []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+
+// gridEntry is a group taking place during a cell of the grid.
+type gridEntry struct {
+	Group    uint
+	Type     repy.GroupType
+	Location string
+}
+
+type gridRow struct {
+	Time  repy.MinutesSinceMidnight
+	Cells [][]gridEntry
+}
+
+// grid is a course's weekly schedule, with a row per gridStep minutes and a
+// column per day.
+type grid struct {
+	Days []time.Weekday
+	Rows []gridRow
+}
+
+// CSSClass returns the class used to color e by its group type.
+func (e gridEntry) CSSClass() string {
+	return fmt.Sprintf("group-%s", e.Type)
+}
+
+func newGrid(c *repy.Course) grid {
+	var start, end repy.MinutesSinceMidnight
+	first := true
+	for _, g := range c.Groups {
+		for _, ev := range g.Events {
+			if first || ev.StartMinute < start {
+				start = ev.StartMinute
+			}
+			if first || ev.EndMinute > end {
+				end = ev.EndMinute
+			}
+			first = false
+		}
+	}
+	if first {
+		return grid{}
+	}
+	start -= start % gridStep
+
+	result := grid{Days: gridDays}
+	for t := start; t < end; t += gridStep {
+		row := gridRow{Time: t, Cells: make([][]gridEntry, len(gridDays))}
+		for i, day := range gridDays {
+			for _, g := range c.Groups {
+				for _, ev := range g.Events {
+					if ev.Interval().Overlaps(repy.WeeklyInterval{Day: day, Start: t, End: t + gridStep}) {
+						row.Cells[i] = append(row.Cells[i], gridEntry{Group: g.ID, Type: g.Type, Location: ev.Location})
+					}
+				}
+			}
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	return result
+}
@@ -0,0 +1,272 @@
+// Package site renders a catalog as a static, browsable HTML site, which can
+// be hosted on any static file server. The site has an index of faculties, a
+// page per faculty, course and lecturer, and a search index used by the
+// client-side search on the index page.
+package site
+
+import (
+	"crypto/sha1"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/lutzky/repy"
+	"github.com/pkg/errors"
+)
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+//go:embed static
+var staticFS embed.FS
+
+// SearchIndexFile is the name of the search index within the site.
+const SearchIndexFile = "search.json"
+
+// SearchEntry is a course in the search index.
+type SearchEntry struct {
+	ID        repy.CourseID `json:"id"`
+	Name      string        `json:"name"`
+	Faculties []string      `json:"faculties"`
+	Lecturers []string      `json:"lecturers"`
+	URL       string        `json:"url"`
+}
+
+var dayNames = map[time.Weekday]string{
+	time.Sunday:    "ראשון",
+	time.Monday:    "שני",
+	time.Tuesday:   "שלישי",
+	time.Wednesday: "רביעי",
+	time.Thursday:  "חמישי",
+	time.Friday:    "שישי",
+	time.Saturday:  "שבת",
+}
+
+var groupTypeNames = map[repy.GroupType]string{
+	repy.Lecture:  "הרצאה",
+	repy.Tutorial: "תרגול",
+	repy.Lab:      "מעבדה",
+	repy.Sport:    "ספורט",
+}
+
+var funcs = template.FuncMap{
+	"day":       func(d time.Weekday) string { return dayNames[d] },
+	"groupType": func(t repy.GroupType) string { return groupTypeNames[t] },
+	"date": func(d repy.Date) string {
+		return fmt.Sprintf("%02d/%02d/%04d", d.Day, d.Month, d.Year)
+	},
+	"courseURL":   courseURL,
+	"facultyURL":  facultyURL,
+	"lecturerURL": lecturerURL,
+}
+
+var templates = template.Must(template.New("").Funcs(funcs).ParseFS(templateFS, "templates/*.html"))
+
+func courseURL(id repy.CourseID) string { return fmt.Sprintf("course-%s.html", id) }
+func facultyURL(i int) string           { return fmt.Sprintf("faculty-%d.html", i) }
+
+// lecturerURL is based on a hash of the name, as names are in Hebrew and
+// contain punctuation, which make for awkward file names.
+func lecturerURL(name string) string {
+	sum := sha1.Sum([]byte(name))
+	return fmt.Sprintf("lecturer-%x.html", sum[:5])
+}
+
+type facultyPage struct {
+	Index int
+	*repy.Faculty
+}
+
+type coursePage struct {
+	*repy.Course
+	Faculties []facultyPage
+	Lecturers []string
+	Grid      grid
+}
+
+// lecturerCourse is a course taught by a lecturer, along with the groups they
+// teach in it.
+type lecturerCourse struct {
+	*repy.Course
+	InCharge bool
+	Groups   []*repy.Group
+}
+
+type lecturerPage struct {
+	Name    string
+	Courses []*lecturerCourse
+}
+
+// site is the contents of the site, indexed for rendering.
+type site struct {
+	Faculties []facultyPage
+	courses   []*coursePage
+	lecturers []*lecturerPage
+}
+
+func newSite(c *repy.Catalog) *site {
+	s := &site{}
+	coursesByID := map[repy.CourseID]*coursePage{}
+	lecturersByName := map[string]*lecturerPage{}
+
+	lecturerCourseFor := func(name string, c *repy.Course) *lecturerCourse {
+		l := lecturersByName[name]
+		if l == nil {
+			l = &lecturerPage{Name: name}
+			lecturersByName[name] = l
+			s.lecturers = append(s.lecturers, l)
+		}
+		for _, lc := range l.Courses {
+			if lc.ID == c.ID {
+				return lc
+			}
+		}
+		lc := &lecturerCourse{Course: c}
+		l.Courses = append(l.Courses, lc)
+		return lc
+	}
+
+	for i := range *c {
+		f := facultyPage{Index: i, Faculty: &(*c)[i]}
+		s.Faculties = append(s.Faculties, f)
+		for j := range f.Courses {
+			course := &f.Courses[j]
+			if cp, ok := coursesByID[course.ID]; ok {
+				// Cross-listed courses get a single page, based on their first
+				// appearance.
+				cp.Faculties = append(cp.Faculties, f)
+				continue
+			}
+			cp := &coursePage{Course: course, Faculties: []facultyPage{f}, Grid: newGrid(course)}
+			coursesByID[course.ID] = cp
+			s.courses = append(s.courses, cp)
+
+			if course.LecturerInCharge != "" {
+				lecturerCourseFor(course.LecturerInCharge, course).InCharge = true
+			}
+			for k := range course.Groups {
+				g := &course.Groups[k]
+				for _, t := range g.Teachers {
+					lc := lecturerCourseFor(t, course)
+					lc.Groups = append(lc.Groups, g)
+				}
+			}
+			cp.Lecturers = lecturerNames(course)
+		}
+	}
+
+	sort.Slice(s.lecturers, func(i, j int) bool { return s.lecturers[i].Name < s.lecturers[j].Name })
+	return s
+}
+
+// lecturerNames returns the lecturer in charge of c followed by the other
+// teachers of its groups. It is never nil, so that the search index has an
+// empty list for courses without lecturers.
+func lecturerNames(c *repy.Course) []string {
+	result := []string{}
+	seen := map[string]bool{"": true}
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			result = append(result, name)
+		}
+	}
+	add(c.LecturerInCharge)
+	for _, g := range c.Groups {
+		for _, t := range g.Teachers {
+			add(t)
+		}
+	}
+	return result
+}
+
+func (s *site) searchIndex() []SearchEntry {
+	result := []SearchEntry{}
+	for _, c := range s.courses {
+		e := SearchEntry{
+			ID:        c.ID,
+			Name:      c.Name,
+			Faculties: []string{},
+			Lecturers: c.Lecturers,
+			URL:       courseURL(c.ID),
+		}
+		for _, f := range c.Faculties {
+			e.Faculties = append(e.Faculties, f.Name)
+		}
+		result = append(result, e)
+	}
+	return result
+}
+
+// Write renders c as a static site into dir, creating it if necessary. Title
+// is shown on every page, e.g. the semester's name.
+func Write(c *repy.Catalog, dir, title string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Wrapf(err, "failed to create %q", dir)
+	}
+	s := newSite(c)
+
+	render := func(filename, tmpl string, data interface{}) error {
+		f, err := os.Create(filepath.Join(dir, filename))
+		if err != nil {
+			return err
+		}
+		if err := templates.ExecuteTemplate(f, tmpl, data); err != nil {
+			f.Close()
+			return errors.Wrapf(err, "failed to render %q", filename)
+		}
+		return f.Close()
+	}
+
+	type page struct {
+		Title string
+		Page  interface{}
+	}
+
+	if err := render("index.html", "index.html", page{title, s}); err != nil {
+		return err
+	}
+	for _, f := range s.Faculties {
+		if err := render(facultyURL(f.Index), "faculty.html", page{title, f}); err != nil {
+			return err
+		}
+	}
+	for _, c := range s.courses {
+		if err := render(courseURL(c.ID), "course.html", page{title, c}); err != nil {
+			return err
+		}
+	}
+	if err := render("lecturers.html", "lecturers.html", page{title, s.lecturers}); err != nil {
+		return err
+	}
+	for _, l := range s.lecturers {
+		if err := render(lecturerURL(l.Name), "lecturer.html", page{title, l}); err != nil {
+			return err
+		}
+	}
+
+	index, err := json.Marshal(s.searchIndex())
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal search index")
+	}
+	if err := os.WriteFile(filepath.Join(dir, SearchIndexFile), index, 0644); err != nil {
+		return err
+	}
+
+	return fs.WalkDir(staticFS, "static", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := staticFS.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(dir, d.Name()), data, 0644)
+	})
+}
@@ -0,0 +1,132 @@
+package site
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lutzky/repy"
+)
+
+func readCatalog(t *testing.T, names ...string) *repy.Catalog {
+	t.Helper()
+	var result repy.Catalog
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join("..", "testdata", name+".json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var c repy.Catalog
+		if err := json.Unmarshal(data, &c); err != nil {
+			t.Fatalf("Failed to unmarshal %s: %v", name, err)
+		}
+		result = append(result, c...)
+	}
+	return &result
+}
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	catalog := readCatalog(t, "course_biology_1", "course_sport_sample")
+	(*catalog)[0].Courses = append((*catalog)[0].Courses, repy.Course{ID: "134999", Name: "ללא מרצה"})
+	if err := Write(catalog, dir, "חורף תשע\"ט"); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	read := func(name string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+		return string(data)
+	}
+
+	testCases := []struct {
+		file string
+		want []string
+	}{
+		{"index.html", []string{`dir="rtl"`, "faculty-0.html", "faculty-1.html", "search.js"}},
+		{"faculty-0.html", []string{"course-134058.html", "ביולוגיה 1"}},
+		{"course-134058.html", []string{
+			"ביולוגיה 1",
+			"11/02/2019",
+			`class="grid"`,
+			"group-lecture",
+			lecturerURL(`ד"ר ד.מאירי`),
+		}},
+		{"lecturers.html", []string{lecturerURL(`ד"ר ד.מאירי`)}},
+		{lecturerURL(`ד"ר ד.מאירי`), []string{"course-134058.html", "מרצה אחראי"}},
+		{"style.css", []string{".group-sport"}},
+	}
+
+	for _, tc := range testCases {
+		got := read(tc.file)
+		for _, want := range tc.want {
+			if !strings.Contains(got, want) {
+				t.Errorf("%s doesn't contain %q", tc.file, want)
+			}
+		}
+	}
+
+	var index []SearchEntry
+	if err := json.Unmarshal([]byte(read(SearchIndexFile)), &index); err != nil {
+		t.Fatalf("Failed to unmarshal search index: %v", err)
+	}
+	var ids []repy.CourseID
+	for _, e := range index {
+		ids = append(ids, e.ID)
+		if _, err := os.Stat(filepath.Join(dir, e.URL)); err != nil {
+			t.Errorf("Search entry %s links to missing page: %v", e.ID, err)
+		}
+	}
+	if d := cmp.Diff([]repy.CourseID{"134058", "134999", "394902"}, ids); d != "" {
+		t.Errorf("Search index IDs diff -want +got:\n%s", d)
+	}
+
+	// search.js expects lists, even for courses without lecturers.
+	var rawIndex []map[string]interface{}
+	if err := json.Unmarshal([]byte(read(SearchIndexFile)), &rawIndex); err != nil {
+		t.Fatalf("Failed to unmarshal search index: %v", err)
+	}
+	for _, e := range rawIndex {
+		if e["id"] != "134999" {
+			continue
+		}
+		if d := cmp.Diff([]interface{}{}, e["lecturers"]); d != "" {
+			t.Errorf("Lecturers of course without lecturers diff -want +got:\n%s", d)
+		}
+	}
+}
+
+func TestGrid(t *testing.T) {
+	c := &repy.Course{Groups: []repy.Group{
+		{ID: 10, Type: repy.Lecture, Events: []repy.Event{{Day: 0, StartMinute: 525, EndMinute: 600}}},
+		{ID: 11, Type: repy.Tutorial, Events: []repy.Event{{Day: 0, StartMinute: 540, EndMinute: 570}}},
+	}}
+	g := newGrid(c)
+
+	var got [][]uint
+	for _, row := range g.Rows {
+		var groups []uint
+		for _, e := range row.Cells[0] {
+			groups = append(groups, e.Group)
+		}
+		got = append(got, groups)
+	}
+	// 08:45 (rounded down to 08:30) until 10:00
+	want := [][]uint{{10}, {10, 11}, {10}}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Sunday column diff -want +got:\n%s", d)
+	}
+	if g.Rows[0].Time != 510 {
+		t.Errorf("First row at %s; want 08:30", g.Rows[0].Time)
+	}
+
+	if g := newGrid(&repy.Course{}); len(g.Rows) != 0 {
+		t.Errorf("Grid of course without events has %d rows; want 0", len(g.Rows))
+	}
+}
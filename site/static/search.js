@@ -0,0 +1,39 @@
+// Client-side course search, using the search index written by the site
+// generator.
+(function() {
+  const input = document.getElementById("search-input");
+  const results = document.getElementById("search-results");
+  const maxResults = 50;
+  let index = [];
+
+  fetch("search.json")
+    .then((response) => response.json())
+    .then((entries) => { index = entries; search(); });
+
+  function matches(entry, query) {
+    return entry.id.includes(query) ||
+      entry.name.includes(query) ||
+      (entry.lecturers || []).some((l) => l.includes(query));
+  }
+
+  function search() {
+    const query = input.value.trim();
+    results.innerHTML = "";
+    if (query === "") {
+      return;
+    }
+    index.filter((entry) => matches(entry, query))
+      .slice(0, maxResults)
+      .forEach((entry) => {
+        const li = document.createElement("li");
+        const a = document.createElement("a");
+        a.href = entry.url;
+        a.textContent = entry.id + " " + entry.name;
+        li.appendChild(a);
+        li.appendChild(document.createTextNode(" (" + (entry.faculties || []).join(", ") + ")"));
+        results.appendChild(li);
+      });
+  }
+
+  input.addEventListener("input", search);
+})();
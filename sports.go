@@ -0,0 +1,294 @@
+package repy
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Gender is the audience of a sports group.
+type Gender string
+
+// Genders of sports groups. The empty Gender means the description doesn't
+// say.
+const (
+	GenderMen   Gender = "men"
+	GenderWomen Gender = "women"
+	GenderMixed Gender = "mixed"
+)
+
+// SportsLevel is the level of a sports group. The empty SportsLevel means the
+// description doesn't say.
+type SportsLevel string
+
+// Levels of sports groups. SportsLevelTeam is used for the groups of
+// representative teams (נבחרות).
+const (
+	SportsLevelBeginners    SportsLevel = "beginners"
+	SportsLevelIntermediate SportsLevel = "intermediate"
+	SportsLevelAdvanced     SportsLevel = "advanced"
+	SportsLevelTeam         SportsLevel = "team"
+)
+
+// SportsInfo is the structured form of a sports group's description, such as
+// "טניס-מתחיל-מעורב".
+type SportsInfo struct {
+	// Activity is the name of the sport, with common abbreviations and
+	// spelling variants normalized, e.g. "כדורסל" for both "כ.סל" and
+	// "כדור סל".
+	Activity string      `json:"activity"`
+	Gender   Gender      `json:"gender,omitempty"`
+	Level    SportsLevel `json:"level,omitempty"`
+
+	// MedicineOnly is set for groups meant for medical students.
+	MedicineOnly bool `json:"medicineOnly,omitempty"`
+
+	// Unparsed holds the qualifiers which weren't recognized, as they appear
+	// in the description, e.g. "שלב ב'" of "טניס - שלב ב'".
+	Unparsed []string `json:"unparsed,omitempty"`
+}
+
+// sportsPhrases normalizes sports descriptions before they are split into
+// words. Hyphenated names are joined, so that hyphens only separate
+// qualifiers, and abbreviations and spelling variants are expanded to the most
+// common spelling of the same name. Longer phrases come first. Each mapping is
+// based on descriptions in published REPY files, an example of which is given
+// (along with the file in testdata in which it appears); unknown spellings
+// are left as they are.
+var sportsPhrases = strings.NewReplacer(
+	"קט-רגל", "קטרגל", // "קט-רגל-בנים-מתקד", spring_2019_v1.repy
+	"כדור-רגל", "כדורגל", // "כדור-רגל-בנים.", winter_2016.repy
+	"כדור-יד", "כדוריד", // "כדור-יד-בנים.", course_sport_sample.repy
+	"כדור יד", "כדוריד", // "כדור יד - בנות", winter_2019_v1.repy
+	"כדור סל", "כדורסל", // "כדור סל - רפואה", spring_2019_v1.repy
+	"כדור עף", "כדורעף", // "כדור עף - בנות", spring_2019_v1.repy
+	"כ.סל", "כדורסל", // "כ.סל-בנות-מתחיל'", spring_2019_v1.repy
+	"כ.עף-חופ.", "כדורעף חופים-", // "כ.עף-חופ.בנים-מת", spring_2019_v1.repy
+	"כ.עף-חופים", "כדורעף חופים", // "כ.עף-חופים-בנות", spring_2019_v1.repy
+	"כ.עף", "כדורעף", // "כ.עף-מתקדמים", spring_2019_v1.repy
+	"טניס-שולחן", "טניס שולחן", // "טניס-שולחן-מעורב", course_sport_sample.repy
+	"ט.שולחן", "טניס שולחן", // "ט.שולחן-מתק-מעור", spring_2019_v1.repy
+	"ניווט-ספורט", "ניווט ספורט", // "ניווט-ספורט-מעור", spring_2019_v1.repy
+	"רכיבה-אופני הרים", "אופני הרים", // "רכיבה-אופני הרים", winter_2019_v1.repy
+	"אופנ.הרי", "אופני הרים", // "אופנ.הרי-מתח-בנו", winter_2016.repy
+	"אתלטיק.קלה", "אתלטיקה קלה", // "אתלטיק.קלה-מעורב", spring_2019_v1.repy
+	"אתל.קלה", "אתלטיקה קלה", // "אתל.קלה- מעורב.", course_sport_sample.repy
+	"התעמ.מכשיר", "התעמלות מכשירים", // "התעמ.מכשיר-בנות", winter_2016.repy
+	"ה.מכשיר", "התעמלות מכשירים", // "ה.מכשיר-מתקד-מעו", winter_2016.repy
+	"ח.כושר", "חדר כושר", // "ח.כושר-רפואה", spring_2019_v1.repy
+	"כושר - אקסטרים", "כושר אקסטרים", // "כושר - אקסטרים", winter_2016.repy
+	"כ.אקסטרים", "כושר אקסטרים", // "כ.אקסטרים-מעורב", winter_2016.repy
+	"טי.אר.אקס", "טיאראקס", // "טי.אר.אקס-בסיסי", spring_2019_v1.repy
+	"להקת - מ ח ו ל", "להקת מחול", // "להקת - מ ח ו ל .", course_sport_sample.repy
+	"בדמינגטון", "בדמינטון", // "בדמינגטון-מתחילי", spring_2019_v1.repy
+	"אקווה", "אקוה", // "אקווה זומבה", winter_2016.repy
+	"פילטיס", "פילאטיס", // "פילטיס", winter_2016.repy
+	"קאנגו", "קנגו", // "אירובי - קאנגו", winter_2019_v1.repy
+	"קייאקים", "קייקים", // "קייאקים", course_sport_sample.repy
+	"טייקונדו", "טייקוונדו", // "טייקונדו-מתק-מעו", spring_2019_v1.repy
+	"סייף", "סיוף", // "סייף - מעורב.", spring_2019_v1.repy
+	"השרדות", "הישרדות", // "השרדות הגנה-בנות", spring_2019_v1.repy
+	"התעמל' פלדנקרייז", "פלדנקרייז", // "התעמל' פלדנקרייז", spring_2019_v1.repy
+	"פלדנ-", "פלדנקרייז-", // "פלדנ-יציבה נכונה", winter_2016.repy
+)
+
+// sportsQualifiers maps words (or their truncations, as REPY descriptions are
+// limited in length) to the fields they set.
+var sportsQualifiers = map[string]func(*SportsInfo){
+	"בנים":  setGender(GenderMen),
+	"בני":   setGender(GenderMen),
+	"לבנים": setGender(GenderMen),
+	"דתיים": setGender(GenderMen), // "שחיה לבנים דתיים", for religious men
+	"בנות":  setGender(GenderWomen),
+	"בנו":   setGender(GenderWomen),
+	"לבנות": setGender(GenderWomen),
+	"דתיות": setGender(GenderWomen), // "שחיה לבנות דתיות", for religious women
+	"מעורב": setGender(GenderMixed),
+	"מעור":  setGender(GenderMixed),
+	"מעו":   setGender(GenderMixed),
+
+	"מתחילים":  setLevel(SportsLevelBeginners),
+	"מתחילי":   setLevel(SportsLevelBeginners),
+	"מתחיל":    setLevel(SportsLevelBeginners),
+	"מתח":      setLevel(SportsLevelBeginners),
+	"בסיסי":    setLevel(SportsLevelBeginners),
+	"בינוניים": setLevel(SportsLevelIntermediate),
+	"בינוני":   setLevel(SportsLevelIntermediate),
+	"בינונ":    setLevel(SportsLevelIntermediate),
+	"מתקדמים":  setLevel(SportsLevelAdvanced),
+	"מתקדם":    setLevel(SportsLevelAdvanced),
+	"מתקדמ":    setLevel(SportsLevelAdvanced),
+	"מתקד":     setLevel(SportsLevelAdvanced),
+	"מתק":      setLevel(SportsLevelAdvanced),
+
+	"רפואה":   setMedicineOnly,
+	"רפואנים": setMedicineOnly,
+	"רפו":     setMedicineOnly,
+
+	// "Only", as in "ג'אז-בנות בלבד", adds no information.
+	"בלבד": func(*SportsInfo) {},
+
+	// "מת" is a truncation of both "מתחילים" and "מתקדמים" (as in
+	// "כ.עף-חופ.בנים-מת"), so the level is deliberately left unknown.
+	"מת": func(*SportsInfo) {},
+}
+
+func setGender(g Gender) func(*SportsInfo) {
+	return func(si *SportsInfo) { si.Gender = g }
+}
+
+func setLevel(l SportsLevel) func(*SportsInfo) {
+	return func(si *SportsInfo) { si.Level = l }
+}
+
+func setMedicineOnly(si *SportsInfo) { si.MedicineOnly = true }
+
+// ParseSportsDescription parses the description of a sports group, which is
+// the activity followed by hyphen-separated qualifiers, such as
+// "טניס-מתחיל-מעורב". Words of the activity which are qualifiers (as in
+// "שחיה לבנות דתיות") are applied rather than being part of it. Words
+// following the activity which aren't recognized as qualifiers are kept in
+// Unparsed, as they were.
+func ParseSportsDescription(description string) SportsInfo {
+	s := strings.Trim(description, ` ."`)
+	if strings.HasPrefix(s, "ש.") {
+		// Swimming groups are abbreviated, e.g. "ש.מתחילים-מעורב".
+		s = "שחיה-" + strings.TrimPrefix(s, "ש.")
+	}
+	s = sportsPhrases.Replace(s)
+
+	var info SportsInfo
+	var activity []string
+	for i, segment := range strings.Split(s, "-") {
+		var unknown []string
+		words := strings.FieldsFunc(segment, func(r rune) bool {
+			return r == ' ' || r == '.'
+		})
+		for _, w := range words {
+			w = strings.Trim(w, `"`)
+			if set, ok := sportsQualifiers[strings.TrimSuffix(w, "'")]; ok {
+				set(&info)
+			} else if w != "" {
+				unknown = append(unknown, w)
+			}
+		}
+		if i == 0 {
+			activity = unknown
+		} else if len(unknown) > 0 {
+			info.Unparsed = append(info.Unparsed, strings.Join(unknown, " "))
+		}
+	}
+	info.Activity = strings.Join(activity, " ")
+	return info
+}
+
+// SportsGroup is a group of a course in the sports faculty.
+type SportsGroup struct {
+	CourseID   CourseID `json:"courseId"`
+	CourseName string   `json:"courseName"`
+	Group      Group    `json:"group"`
+}
+
+// SportsByActivity returns all sports groups in c which have SportsInfo,
+// keyed by activity and sorted by course and group ID.
+func (c *Catalog) SportsByActivity() map[string][]SportsGroup {
+	result := map[string][]SportsGroup{}
+	for _, f := range *c {
+		for _, course := range f.Courses {
+			for _, g := range course.Groups {
+				if g.Sports == nil {
+					continue
+				}
+				result[g.Sports.Activity] = append(result[g.Sports.Activity], SportsGroup{
+					CourseID:   course.ID,
+					CourseName: course.Name,
+					Group:      g,
+				})
+			}
+		}
+	}
+	for _, groups := range result {
+		sort.SliceStable(groups, func(i, j int) bool {
+			if groups[i].CourseID != groups[j].CourseID {
+				return groups[i].CourseID < groups[j].CourseID
+			}
+			return groups[i].Group.ID < groups[j].Group.ID
+		})
+	}
+	return result
+}
+
+// SportsFilter selects sports groups, e.g. mixed beginner swimming on Monday
+// evenings. Zero-valued fields match all groups.
+type SportsFilter struct {
+	// Activity, Gender and Level must match those of the group's SportsInfo
+	// exactly.
+	Activity string
+	Gender   Gender
+	Level    SportsLevel
+
+	// Day, if not nil, is a day on which the group has an event between From
+	// and To. Without Day, From and To apply to events on any day. To defaults
+	// to EndOfDay.
+	Day      *time.Weekday
+	From, To MinutesSinceMidnight
+}
+
+// Match returns whether sg matches the filter.
+func (sf SportsFilter) Match(sg SportsGroup) bool {
+	info := sg.Group.Sports
+	if info == nil {
+		return false
+	}
+	if sf.Activity != "" && info.Activity != sf.Activity {
+		return false
+	}
+	if sf.Gender != "" && info.Gender != sf.Gender {
+		return false
+	}
+	if sf.Level != "" && info.Level != sf.Level {
+		return false
+	}
+	if sf.Day == nil && sf.From == 0 && sf.To == 0 {
+		return true
+	}
+	to := sf.To
+	if to == 0 {
+		to = EndOfDay
+	}
+	for _, ev := range sg.Group.Events {
+		window := WeeklyInterval{Day: ev.Day, Start: sf.From, End: to}
+		if sf.Day != nil {
+			window.Day = *sf.Day
+		}
+		if iv := ev.Interval(); iv.Intersect(window) == iv {
+			return true
+		}
+	}
+	return false
+}
+
+// SportsGroups returns the sports groups in c which match sf, sorted by
+// activity, and then as in SportsByActivity.
+func (c *Catalog) SportsGroups(sf SportsFilter) []SportsGroup {
+	byActivity := c.SportsByActivity()
+	var activities []string
+	if sf.Activity != "" {
+		activities = []string{sf.Activity}
+	} else {
+		for activity := range byActivity {
+			activities = append(activities, activity)
+		}
+		sort.Strings(activities)
+	}
+
+	result := []SportsGroup{}
+	for _, activity := range activities {
+		for _, sg := range byActivity[activity] {
+			if sf.Match(sg) {
+				result = append(result, sg)
+			}
+		}
+	}
+	return result
+}
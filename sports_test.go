@@ -0,0 +1,135 @@
+package repy
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseSportsDescription(t *testing.T) {
+	testCases := []struct {
+		description string
+		want        SportsInfo
+	}{
+		{"טניס-מתחיל-מעורב", SportsInfo{Activity: "טניס", Gender: GenderMixed, Level: SportsLevelBeginners}},
+		{"ש.מתחילים-מעורב.", SportsInfo{Activity: "שחיה", Gender: GenderMixed, Level: SportsLevelBeginners}},
+		{"ש.שלב ב'-מעורב", SportsInfo{Activity: "שחיה", Gender: GenderMixed, Unparsed: []string{"שלב ב'"}}},
+		{"טניס - שלב ב'", SportsInfo{Activity: "טניס", Unparsed: []string{"שלב ב'"}}},
+		{"שחיה לבנות דתיות", SportsInfo{Activity: "שחיה", Gender: GenderWomen}},
+		{"שחיה לבנים דתיים", SportsInfo{Activity: "שחיה", Gender: GenderMen}},
+		{"כ.עף-חופ.בנים-מת", SportsInfo{Activity: "כדורעף חופים", Gender: GenderMen}},
+		{"אירובי - קאנגו", SportsInfo{Activity: "אירובי", Unparsed: []string{"קנגו"}}},
+		{"פלדנ-יציבה נכונה", SportsInfo{Activity: "פלדנקרייז", Unparsed: []string{"יציבה נכונה"}}},
+		{"קט-רגל-בנים-מתקד", SportsInfo{Activity: "קטרגל", Gender: GenderMen, Level: SportsLevelAdvanced}},
+		{"כ.סל-בנות-מתחיל'", SportsInfo{Activity: "כדורסל", Gender: GenderWomen, Level: SportsLevelBeginners}},
+		{"כדור סל - רפואה", SportsInfo{Activity: "כדורסל", MedicineOnly: true}},
+		{"אופנ.הרי-מתח-בנו", SportsInfo{Activity: "אופני הרים", Gender: GenderWomen, Level: SportsLevelBeginners}},
+		{`"היט" - מעורב`, SportsInfo{Activity: "היט", Gender: GenderMixed}},
+		{"ג'יו ג'יטסו", SportsInfo{Activity: "ג'יו ג'יטסו"}},
+		{"", SportsInfo{}},
+	}
+
+	for _, tc := range testCases {
+		if d := cmp.Diff(tc.want, ParseSportsDescription(tc.description)); d != "" {
+			t.Errorf("ParseSportsDescription(%q) diff -want +got:\n%s", tc.description, d)
+		}
+	}
+}
+
+func TestSportsByActivity(t *testing.T) {
+	c, err := ReadFile(bytes.NewReader(readTestREPY(t, "testdata/course_sport_sample.repy")), nil)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+
+	byActivity := c.SportsByActivity()
+	squash := byActivity["סקווש"]
+	if len(squash) == 0 {
+		t.Fatalf("No squash groups in %v", byActivity)
+	}
+	for i, sg := range squash {
+		if sg.Group.Sports.Activity != "סקווש" {
+			t.Errorf("Group %+v listed under squash", sg.Group)
+		}
+		if sg.Group.Sports.Level != SportsLevelTeam {
+			t.Errorf("Group %+v of %q has level %q; want %q", sg.Group, sg.CourseName, sg.Group.Sports.Level, SportsLevelTeam)
+		}
+		if i > 0 && sg.CourseID == squash[i-1].CourseID && sg.Group.ID <= squash[i-1].Group.ID {
+			t.Errorf("Squash groups aren't sorted: %d after %d", sg.Group.ID, squash[i-1].Group.ID)
+		}
+	}
+}
+
+func TestSportsGroups(t *testing.T) {
+	group := func(id uint, description string, day time.Weekday, start, end MinutesSinceMidnight) Group {
+		sports := ParseSportsDescription(description)
+		return Group{
+			ID:          id,
+			Description: description,
+			Type:        Sport,
+			Events:      []Event{{Day: day, StartMinute: start, EndMinute: end}},
+			Sports:      &sports,
+		}
+	}
+	catalog := Catalog{{Courses: []Course{
+		{ID: "394806", Groups: []Group{
+			group(11, "ש.מתחילים-מעורב", time.Monday, 1080, 1170),
+			group(12, "ש.מתחילים-מעורב", time.Monday, 600, 690),
+			group(13, "ש.מתקדמים-מעורב", time.Monday, 1080, 1170),
+			group(14, "שחיה לבנות דתיות", time.Monday, 1080, 1170),
+			group(15, "ש.מתחילים-מעורב", time.Tuesday, 1080, 1170),
+		}},
+		{ID: "394807", Groups: []Group{
+			group(11, "טניס-מתחיל-מעורב", time.Monday, 1080, 1170),
+			group(12, "טניס - שלב ב'", time.Monday, 1080, 1170),
+		}},
+	}}}
+
+	monday := time.Monday
+	testCases := []struct {
+		name   string
+		filter SportsFilter
+		want   []GroupRef
+	}{
+		{
+			name:   "all",
+			filter: SportsFilter{},
+			// Sorted by activity, and "טניס" comes before "שחיה".
+			want: []GroupRef{{"394807", 11}, {"394807", 12}, {"394806", 11}, {"394806", 12}, {"394806", 13}, {"394806", 14}, {"394806", 15}},
+		},
+		{
+			name:   "mixed beginner swimming on monday evening",
+			filter: SportsFilter{Activity: "שחיה", Gender: GenderMixed, Level: SportsLevelBeginners, Day: &monday, From: 17 * 60},
+			want:   []GroupRef{{"394806", 11}},
+		},
+		{
+			name:   "women",
+			filter: SportsFilter{Gender: GenderWomen},
+			want:   []GroupRef{{"394806", 14}},
+		},
+		{
+			name:   "any day in the morning",
+			filter: SportsFilter{To: 12 * 60},
+			want:   []GroupRef{{"394806", 12}},
+		},
+		{
+			name:   "unknown activity",
+			filter: SportsFilter{Activity: "קריקט"},
+			want:   []GroupRef{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := []GroupRef{}
+			for _, sg := range catalog.SportsGroups(tc.filter) {
+				got = append(got, GroupRef{sg.CourseID, sg.Group.ID})
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("SportsGroups diff -want +got:\n%s", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package repy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ProblemKind classifies the problems found by Validate.
+type ProblemKind string
+
+// Problem kinds reported by Validate.
+const (
+	ProblemEventTimes          ProblemKind = "event_times"
+	ProblemOutsideTeachingDay  ProblemKind = "outside_teaching_day"
+	ProblemDuplicateGroup      ProblemKind = "duplicate_group"
+	ProblemGroupWithoutEvents  ProblemKind = "group_without_events"
+	ProblemCourseWithoutGroups ProblemKind = "course_without_groups"
+	ProblemWeeklyHours         ProblemKind = "weekly_hours"
+	ProblemExamOutsideSemester ProblemKind = "exam_outside_semester"
+	ProblemCourseIDPrefix      ProblemKind = "course_id_prefix"
+)
+
+// Problem is a violated invariant in a catalog. CourseID and GroupID are empty
+// or zero if the problem doesn't concern a specific course or group.
+type Problem struct {
+	Kind     ProblemKind `json:"kind"`
+	Faculty  string      `json:"faculty"`
+	CourseID CourseID    `json:"courseId,omitempty"`
+	GroupID  uint        `json:"groupId,omitempty"`
+	Message  string      `json:"message"`
+}
+
+func (p Problem) String() string {
+	var where []string
+	if p.Faculty != "" {
+		where = append(where, p.Faculty)
+	}
+	if p.CourseID != "" {
+		where = append(where, fmt.Sprintf("course %s", p.CourseID))
+	}
+	if p.GroupID != 0 {
+		where = append(where, fmt.Sprintf("group %d", p.GroupID))
+	}
+	return fmt.Sprintf("%s: %s: %s", strings.Join(where, ", "), p.Kind, p.Message)
+}
+
+// Events are expected to take place between TeachingDayStart and
+// TeachingDayEnd.
+const (
+	TeachingDayStart MinutesSinceMidnight = 7 * 60
+	TeachingDayEnd   MinutesSinceMidnight = 23*60 + 30
+)
+
+// Validate checks c for inconsistencies which the parser doesn't catch, such as
+// events ending before they start, or exams outside of the semester. Course
+// IDs are expected to share their first two digits (as a 6-digit number, or
+// the corresponding digits of an 8-digit one) with most courses in their
+// faculty.
+func Validate(c *Catalog) []Problem {
+	var problems []Problem
+	for _, f := range *c {
+		problems = append(problems, validateFaculty(f)...)
+	}
+	return problems
+}
+
+// facultyPrefix returns the ID prefix shared by most courses in f.
+func facultyPrefix(f Faculty) string {
+	counts := map[string]int{}
+	var best string
+	for _, course := range f.Courses {
+		prefix := course.ID.prefix()
+		counts[prefix]++
+		if counts[prefix] > counts[best] || (counts[prefix] == counts[best] && prefix < best) {
+			best = prefix
+		}
+	}
+	return best
+}
+
+func validateFaculty(f Faculty) []Problem {
+	var problems []Problem
+	add := func(kind ProblemKind, courseID CourseID, groupID uint, format string, a ...interface{}) {
+		problems = append(problems, Problem{
+			Kind:     kind,
+			Faculty:  f.Name,
+			CourseID: courseID,
+			GroupID:  groupID,
+			Message:  fmt.Sprintf(format, a...),
+		})
+	}
+
+	prefix := facultyPrefix(f)
+	first, last, semesterKnown := examPeriod(f.Semester)
+
+	for _, course := range f.Courses {
+		if course.ID.prefix() != prefix {
+			add(ProblemCourseIDPrefix, course.ID, 0, "ID has faculty prefix %s rather than %s, like most courses in the faculty", course.ID.prefix(), prefix)
+		}
+
+		if len(course.Groups) == 0 {
+			add(ProblemCourseWithoutGroups, course.ID, 0, "course has no groups")
+		}
+
+		seen := map[uint]bool{}
+		for _, g := range course.Groups {
+			if seen[g.ID] {
+				add(ProblemDuplicateGroup, course.ID, g.ID, "group ID appears more than once")
+			}
+			seen[g.ID] = true
+
+			if len(g.Events) == 0 {
+				add(ProblemGroupWithoutEvents, course.ID, g.ID, "%s group has no events", g.Type)
+			}
+			for _, ev := range g.Events {
+				if ev.Interval().Empty() {
+					add(ProblemEventTimes, course.ID, g.ID, "event on %s ends at %s, not after it starts at %s", ev.Day, ev.EndMinute, ev.StartMinute)
+				} else if ev.StartMinute < TeachingDayStart || ev.EndMinute > TeachingDayEnd {
+					add(ProblemOutsideTeachingDay, course.ID, g.ID, "event on %s at %s-%s is outside %s-%s", ev.Day, ev.StartMinute, ev.EndMinute, TeachingDayStart, TeachingDayEnd)
+				}
+			}
+		}
+
+		for _, mismatch := range weeklyHoursMismatches(course) {
+			add(ProblemWeeklyHours, course.ID, 0, "%s", mismatch)
+		}
+
+		if semesterKnown {
+			for _, d := range course.TestDates {
+				if d.Before(first) || d.After(last) {
+					add(ProblemExamOutsideSemester, course.ID, 0, "exam on %s is outside %s (%s to %s)", d, f.Semester, first, last)
+				}
+			}
+		}
+	}
+
+	return problems
+}
+
+// weeklyHoursMismatches describes group types for which the course has weekly
+// hours, but none of its groups of that type has events adding up to them.
+func weeklyHoursMismatches(course Course) []string {
+	hours := map[GroupType]uint{
+		Lecture:  course.WeeklyHours.Lecture,
+		Tutorial: course.WeeklyHours.Tutorial,
+		Lab:      course.WeeklyHours.Lab,
+	}
+
+	durations := map[GroupType][]MinutesSinceMidnight{}
+	for _, g := range course.Groups {
+		if _, ok := hours[g.Type]; !ok || len(g.Events) == 0 {
+			continue
+		}
+		var total MinutesSinceMidnight
+		for _, ev := range g.Events {
+			total += ev.Interval().Length()
+		}
+		durations[g.Type] = append(durations[g.Type], total)
+	}
+
+	var result []string
+	for _, gt := range []GroupType{Lecture, Tutorial, Lab} {
+		if hours[gt] == 0 || len(durations[gt]) == 0 {
+			continue
+		}
+		want := MinutesSinceMidnight(hours[gt] * 60)
+		matched := false
+		var got []string
+		for _, d := range durations[gt] {
+			matched = matched || d == want
+			got = append(got, fmt.Sprintf("%d:%02d", d/60, d%60))
+		}
+		if !matched {
+			sort.Strings(got)
+			result = append(result, fmt.Sprintf("%d weekly %s hours, but groups total %s", hours[gt], gt, strings.Join(got, ", ")))
+		}
+	}
+	return result
+}
+
+// hebrewLetterValues maps Hebrew letters to their numerical (gematria) values.
+var hebrewLetterValues = map[rune]int{
+	'א': 1, 'ב': 2, 'ג': 3, 'ד': 4, 'ה': 5, 'ו': 6, 'ז': 7, 'ח': 8, 'ט': 9,
+	'י': 10, 'כ': 20, 'ך': 20, 'ל': 30, 'מ': 40, 'ם': 40, 'נ': 50, 'ן': 50,
+	'ס': 60, 'ע': 70, 'פ': 80, 'ף': 80, 'צ': 90, 'ץ': 90,
+	'ק': 100, 'ר': 200, 'ש': 300, 'ת': 400,
+}
+
+// parseHebrewYear parses years such as `תש"ף`, omitting the thousands, and
+// returns the Gregorian year in which they begin.
+func parseHebrewYear(s string) (int, bool) {
+	year := 0
+	for _, r := range s {
+		if r == '"' || r == '\'' {
+			continue
+		}
+		v, ok := hebrewLetterValues[r]
+		if !ok {
+			return 0, false
+		}
+		year += v
+	}
+	if year == 0 {
+		return 0, false
+	}
+	return 5000 + year - 3761, true
+}
+
+// examPeriod returns the range of dates in which exams of semester (e.g.
+// `חורף תש"ף`) may take place, from the beginning of the semester to the end
+// of its last exam period.
+func examPeriod(semester string) (first, last Date, ok bool) {
+	fields := strings.Fields(semester)
+	if len(fields) != 2 {
+		return first, last, false
+	}
+	year, ok := parseHebrewYear(fields[1])
+	if !ok {
+		return first, last, false
+	}
+
+	date := func(year int, month time.Month, day int) Date {
+		return Date{Year: uint(year), Month: uint(month), Day: uint(day)}
+	}
+	switch fields[0] {
+	case "חורף":
+		return date(year, time.October, 1), date(year+1, time.April, 30), true
+	case "אביב":
+		return date(year+1, time.March, 1), date(year+1, time.November, 30), true
+	case "קיץ":
+		return date(year+1, time.June, 1), date(year+1, time.November, 30), true
+	}
+	return first, last, false
+}
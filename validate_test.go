@@ -0,0 +1,117 @@
+package repy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestValidate(t *testing.T) {
+	event := func(start, end MinutesSinceMidnight) Event {
+		return Event{Day: time.Monday, StartMinute: start, EndMinute: end}
+	}
+	goodCourse := func(id CourseID) Course {
+		return Course{
+			ID:          id,
+			WeeklyHours: WeeklyHours{Lecture: 2},
+			TestDates:   []Date{{2020, 2, 9}},
+			Groups: []Group{
+				{ID: 10, Type: Lecture, Events: []Event{event(600, 720)}},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		modify func(c *Course)
+		want   []ProblemKind
+	}{
+		{"Valid", func(c *Course) {}, nil},
+		{"EndBeforeStart", func(c *Course) {
+			c.Groups[0].Events = append(c.Groups[0].Events, event(600, 600))
+		}, []ProblemKind{ProblemEventTimes}},
+		{"TooEarly", func(c *Course) {
+			c.Groups[0].Events[0] = event(6*60, 8*60)
+		}, []ProblemKind{ProblemOutsideTeachingDay}},
+		{"DuplicateGroup", func(c *Course) {
+			c.Groups = append(c.Groups, c.Groups[0])
+		}, []ProblemKind{ProblemDuplicateGroup}},
+		{"GroupWithoutEvents", func(c *Course) {
+			c.Groups = append(c.Groups, Group{ID: 11, Type: Tutorial})
+		}, []ProblemKind{ProblemGroupWithoutEvents}},
+		{"CourseWithoutGroups", func(c *Course) {
+			c.Groups = nil
+		}, []ProblemKind{ProblemCourseWithoutGroups}},
+		{"WeeklyHours", func(c *Course) {
+			c.WeeklyHours.Lecture = 3
+		}, []ProblemKind{ProblemWeeklyHours}},
+		{"WeeklyHoursOneGroupMatches", func(c *Course) {
+			c.Groups = append(c.Groups, Group{ID: 20, Type: Lecture, Events: []Event{event(600, 660)}})
+		}, nil},
+		{"WeeklyHoursSplitEvents", func(c *Course) {
+			c.WeeklyHours.Tutorial = 2
+			c.Groups = append(c.Groups, Group{ID: 11, Type: Tutorial, Events: []Event{event(600, 660), event(720, 780)}})
+		}, nil},
+		{"ExamOutsideSemester", func(c *Course) {
+			c.TestDates = append(c.TestDates, Date{2020, 7, 1})
+		}, []ProblemKind{ProblemExamOutsideSemester}},
+		{"CourseIDPrefix", func(c *Course) {
+			c.ID = "104031"
+		}, []ProblemKind{ProblemCourseIDPrefix}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			course := goodCourse("234114")
+			tc.modify(&course)
+			catalog := Catalog{{
+				Name:     "מדעי המחשב",
+				Semester: `חורף תש"ף`,
+				Courses:  []Course{goodCourse("234111"), goodCourse("236363"), course},
+			}}
+
+			var got []ProblemKind
+			for _, p := range Validate(&catalog) {
+				got = append(got, p.Kind)
+				if p.CourseID != course.ID {
+					t.Errorf("Problem %v is about course %s; want %s", p, p.CourseID, course.ID)
+				}
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Validate() problem kinds diff -want +got:\n%s", d)
+			}
+		})
+	}
+}
+
+func TestExamPeriod(t *testing.T) {
+	testCases := []struct {
+		semester    string
+		first, last string
+		ok          bool
+	}{
+		{`חורף תש"ף`, "2019-10-01", "2020-04-30", true},
+		{`אביב תשע"ח`, "2018-03-01", "2018-11-30", true},
+		{`קיץ תשע"ו`, "2016-06-01", "2016-11-30", true},
+		{"סתיו תשע", "", "", false},
+		{"", "", "", false},
+	}
+
+	for _, tc := range testCases {
+		first, last, ok := examPeriod(tc.semester)
+		if ok != tc.ok {
+			t.Errorf("examPeriod(%q) ok = %v; want %v", tc.semester, ok, tc.ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if got := first.String(); got != tc.first {
+			t.Errorf("examPeriod(%q) first = %s; want %s", tc.semester, got, tc.first)
+		}
+		if got := last.String(); got != tc.last {
+			t.Errorf("examPeriod(%q) last = %s; want %s", tc.semester, got, tc.last)
+		}
+	}
+}
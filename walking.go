@@ -0,0 +1,166 @@
+package repy
+
+import (
+	"io"
+	"io/ioutil"
+	"math"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// Building returns the building part of e.Location, i.e. without the room
+// number, e.g. "טאוב" for "טאוב 5".
+func (e Event) Building() string {
+	fields := strings.Fields(e.Location)
+	if n := len(fields); n > 1 && strings.IndexFunc(fields[n-1], unicode.IsDigit) != -1 {
+		fields = fields[:n-1]
+	}
+	return strings.Join(fields, " ")
+}
+
+// Coordinates are the location of a building, in degrees.
+type Coordinates struct {
+	Lat float64 `yaml:"lat"`
+	Lon float64 `yaml:"lon"`
+}
+
+// WalkingPair is the walking time between two buildings, in minutes.
+type WalkingPair struct {
+	From    string `yaml:"from"`
+	To      string `yaml:"to"`
+	Minutes int    `yaml:"minutes"`
+}
+
+// DefaultWalkingSpeed is the walking speed, in meters per minute, used to
+// estimate walking times from coordinates.
+const DefaultWalkingSpeed = 70
+
+// WalkingTimes estimates walking times between buildings, using either
+// explicit pairwise walking times or the coordinates of the buildings, as read
+// from a YAML file by ReadWalkingTimes:
+//
+//	walking_speed: 70 # meters per minute
+//	buildings:
+//	  טאוב: {lat: 32.7781, lon: 35.0217}
+//	  אולמן: {lat: 32.7770, lon: 35.0232}
+//	walking_minutes:
+//	  - {from: טאוב, to: פיסיקה, minutes: 8}
+//
+// Pairwise times take precedence over coordinates, and apply in both
+// directions. Buildings are named as in Event.Building.
+type WalkingTimes struct {
+	WalkingSpeed   float64                `yaml:"walking_speed"`
+	Buildings      map[string]Coordinates `yaml:"buildings"`
+	WalkingMinutes []WalkingPair          `yaml:"walking_minutes"`
+}
+
+// ReadWalkingTimes reads walking times in YAML format.
+func ReadWalkingTimes(r io.Reader) (*WalkingTimes, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var w WalkingTimes
+	if err := yaml.UnmarshalStrict(data, &w); err != nil {
+		return nil, errors.Wrap(err, "failed to decode walking times")
+	}
+	if w.WalkingSpeed < 0 {
+		return nil, errors.Errorf("invalid walking speed %v", w.WalkingSpeed)
+	}
+	for _, p := range w.WalkingMinutes {
+		if p.Minutes < 0 {
+			return nil, errors.Errorf("invalid walking time of %d minutes from %q to %q", p.Minutes, p.From, p.To)
+		}
+	}
+	return &w, nil
+}
+
+// Minutes returns the walking time between the buildings from and to, and
+// whether it is known.
+func (w *WalkingTimes) Minutes(from, to string) (int, bool) {
+	if from == to {
+		return 0, true
+	}
+	for _, p := range w.WalkingMinutes {
+		if (p.From == from && p.To == to) || (p.From == to && p.To == from) {
+			return p.Minutes, true
+		}
+	}
+
+	a, okA := w.Buildings[from]
+	b, okB := w.Buildings[to]
+	if !okA || !okB {
+		return 0, false
+	}
+	speed := w.WalkingSpeed
+	if speed == 0 {
+		speed = DefaultWalkingSpeed
+	}
+	return int(math.Ceil(distance(a, b) / speed)), true
+}
+
+// distance returns the distance between a and b, in meters.
+func distance(a, b Coordinates) float64 {
+	const earthRadius = 6371000
+	rad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat, dLon := rad(b.Lat-a.Lat), rad(b.Lon-a.Lon)
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(rad(a.Lat))*math.Cos(rad(b.Lat))*math.Pow(math.Sin(dLon/2), 2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(h))
+}
+
+// TightConnection is a pair of consecutive events, the gap between which is
+// shorter than the walking time between their buildings.
+type TightConnection struct {
+	Day          time.Weekday `json:"day"`
+	From         GroupRef     `json:"from"`
+	FromLocation string       `json:"fromLocation"`
+	To           GroupRef     `json:"to"`
+	ToLocation   string       `json:"toLocation"`
+
+	// GapMinutes and WalkingMinutes are the time between the events, and the
+	// time it takes to walk between them.
+	GapMinutes     int `json:"gapMinutes"`
+	WalkingMinutes int `json:"walkingMinutes"`
+}
+
+// TightConnections returns the consecutive events among events, which must be
+// sorted as by Catalog.Resolve, whose gap is shorter than the walking time
+// between their buildings. Each event is compared with the latest-ending event
+// before it on the same day, so that an event nested in a longer one doesn't
+// hide the longer one's connection. Overlapping events (see Clashes) and events
+// in buildings with unknown walking times are ignored.
+func (w *WalkingTimes) TightConnections(events []ScheduledEvent) []TightConnection {
+	var result []TightConnection
+	latest := 0
+	for i := 1; i < len(events); i++ {
+		a, b := events[latest], events[i]
+		if a.Event.Day != b.Event.Day || b.Event.EndMinute > a.Event.EndMinute {
+			latest = i
+		}
+		if a.Event.Day != b.Event.Day || b.Event.StartMinute < a.Event.EndMinute {
+			continue
+		}
+		if a.Event.Location == "" || b.Event.Location == "" {
+			continue
+		}
+		walking, ok := w.Minutes(a.Event.Building(), b.Event.Building())
+		gap := int(b.Event.StartMinute - a.Event.EndMinute)
+		if !ok || gap >= walking {
+			continue
+		}
+		result = append(result, TightConnection{
+			Day:            a.Event.Day,
+			From:           a.Ref(),
+			FromLocation:   a.Event.Location,
+			To:             b.Ref(),
+			ToLocation:     b.Event.Location,
+			GapMinutes:     gap,
+			WalkingMinutes: walking,
+		})
+	}
+	return result
+}
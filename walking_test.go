@@ -0,0 +1,152 @@
+package repy
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBuilding(t *testing.T) {
+	testCases := []struct {
+		location string
+		want     string
+	}{
+		{"טאוב 5", "טאוב"},
+		{"ה.כימית 3", "ה.כימית"},
+		{"ה.כימית", "ה.כימית"},
+		{"אולם ב'", "אולם ב'"},
+		{"אודי ביורפואי", "אודי ביורפואי"},
+		{"", ""},
+	}
+	for _, tc := range testCases {
+		if got := (Event{Location: tc.location}).Building(); got != tc.want {
+			t.Errorf("Building of %q = %q; want %q", tc.location, got, tc.want)
+		}
+	}
+}
+
+const testWalkingTimes = `
+walking_speed: 60
+buildings:
+  טאוב: {lat: 32.7780, lon: 35.0210}
+  אולמן: {lat: 32.7780, lon: 35.0250}
+walking_minutes:
+  - {from: טאוב, to: פיסיקה, minutes: 8}
+`
+
+func TestWalkingTimesMinutes(t *testing.T) {
+	w, err := ReadWalkingTimes(strings.NewReader(testWalkingTimes))
+	if err != nil {
+		t.Fatalf("ReadWalkingTimes failed: %v", err)
+	}
+
+	testCases := []struct {
+		from, to string
+		want     int
+		wantOK   bool
+	}{
+		{"טאוב", "טאוב", 0, true},
+		{"טאוב", "פיסיקה", 8, true},
+		{"פיסיקה", "טאוב", 8, true},
+		// About 375 meters apart, at 60 meters per minute.
+		{"טאוב", "אולמן", 7, true},
+		{"אולמן", "פיסיקה", 0, false},
+	}
+	for _, tc := range testCases {
+		got, ok := w.Minutes(tc.from, tc.to)
+		if got != tc.want || ok != tc.wantOK {
+			t.Errorf("Minutes(%q, %q) = %d, %v; want %d, %v", tc.from, tc.to, got, ok, tc.want, tc.wantOK)
+		}
+	}
+}
+
+func TestReadWalkingTimesErrors(t *testing.T) {
+	for _, input := range []string{
+		"walking_speed: -1",
+		"walking_minutes: [{from: a, to: b, minutes: -5}]",
+		"buildings: {a: {lat: 1, lon: 2, alt: 3}}",
+	} {
+		if _, err := ReadWalkingTimes(strings.NewReader(input)); err == nil {
+			t.Errorf("ReadWalkingTimes(%q) succeeded; want error", input)
+		}
+	}
+}
+
+func TestTightConnections(t *testing.T) {
+	w, err := ReadWalkingTimes(strings.NewReader(testWalkingTimes))
+	if err != nil {
+		t.Fatalf("ReadWalkingTimes failed: %v", err)
+	}
+
+	event := func(day time.Weekday, start, end MinutesSinceMidnight, location string) Event {
+		return Event{Day: day, StartMinute: start, EndMinute: end, Location: location}
+	}
+	catalog := Catalog{{Courses: []Course{
+		{ID: "234114", Groups: []Group{
+			{ID: 10, Events: []Event{
+				event(time.Sunday, 630, 750, "טאוב 2"),
+				event(time.Monday, 630, 750, "טאוב 2"),
+			}},
+		}},
+		{ID: "114071", Groups: []Group{
+			{ID: 10, Events: []Event{
+				// Right after the Sunday lecture, across campus.
+				event(time.Sunday, 750, 870, "פיסיקה 1"),
+				// 10 minutes after the Monday lecture, which is enough.
+				event(time.Monday, 760, 870, "פיסיקה 1"),
+			}},
+		}},
+		{ID: "104031", Groups: []Group{
+			{ID: 10, Events: []Event{
+				// Right after the Sunday physics lecture, in an unknown
+				// building.
+				event(time.Sunday, 870, 990, "אמדו 232"),
+				// Right after the Monday physics lecture, in the same room.
+				event(time.Monday, 870, 990, "פיסיקה 1"),
+			}},
+		}},
+		{ID: "234141", Groups: []Group{
+			{ID: 10, Events: []Event{
+				// Overlapping events are clashes, rather than tight
+				// connections.
+				event(time.Tuesday, 600, 720, "טאוב 1"),
+				event(time.Tuesday, 700, 800, "פיסיקה 1"),
+			}},
+		}},
+		{ID: "236363", Groups: []Group{
+			{ID: 10, Events: []Event{
+				// A long lab with a shorter event nested in it, and another
+				// event right after the lab, across campus.
+				event(time.Wednesday, 600, 840, "טאוב 3"),
+				event(time.Wednesday, 630, 690, "טאוב 4"),
+				event(time.Wednesday, 840, 900, "פיסיקה 2"),
+			}},
+		}},
+	}}}
+
+	s := Selection{{"234114", 10}, {"114071", 10}, {"104031", 10}, {"234141", 10}, {"236363", 10}}
+	events, _ := catalog.Resolve(s)
+
+	want := []TightConnection{{
+		Day:            time.Sunday,
+		From:           GroupRef{"234114", 10},
+		FromLocation:   "טאוב 2",
+		To:             GroupRef{"114071", 10},
+		ToLocation:     "פיסיקה 1",
+		GapMinutes:     0,
+		WalkingMinutes: 8,
+	}, {
+		Day:            time.Wednesday,
+		From:           GroupRef{"236363", 10},
+		FromLocation:   "טאוב 3",
+		To:             GroupRef{"236363", 10},
+		ToLocation:     "פיסיקה 2",
+		GapMinutes:     0,
+		WalkingMinutes: 8,
+	}}
+	if d := cmp.Diff(want, w.TightConnections(events)); d != "" {
+		t.Errorf("TightConnections diff -want +got:\n%s", d)
+	}
+}
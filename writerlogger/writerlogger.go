@@ -1,31 +1,46 @@
 package writerlogger
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"log/slog"
 
 	"github.com/lutzky/repy"
 )
 
 var _ repy.Logger = Logger{}
 
-// Logger is a repy.Logger that writes to W, an io.Writer
+// Logger is a repy.Logger that writes to W, an io.Writer. Each message is
+// written as a single line, prefixed by the first letter of its level (D, I, W
+// or E).
 type Logger struct {
 	W io.Writer
+
+	// MinLevel is the lowest level written; the zero value omits debug
+	// messages.
+	MinLevel slog.Level
 }
 
-// Infof implements Logger.Infof
-func (wl Logger) Infof(format string, args ...interface{}) {
-	fmt.Fprint(wl.W, "I ")
-	fmt.Fprintf(wl.W, format, args...)
-	fmt.Fprint(wl.W, "\n")
+// Log implements Logger.Log
+func (wl Logger) Log(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	if level < wl.MinLevel {
+		return
+	}
+	fmt.Fprintf(wl.W, "%c %s\n", levelLetter(level), repy.FormatMessage(msg, attrs))
 }
 
-// Warningf implements Logger.Warningf
-func (wl Logger) Warningf(format string, args ...interface{}) {
-	fmt.Fprint(wl.W, "W ")
-	fmt.Fprintf(wl.W, format, args...)
-	fmt.Fprint(wl.W, "\n")
+func levelLetter(level slog.Level) byte {
+	switch {
+	case level >= slog.LevelError:
+		return 'E'
+	case level >= slog.LevelWarn:
+		return 'W'
+	case level >= slog.LevelInfo:
+		return 'I'
+	default:
+		return 'D'
+	}
 }
 
 // Flush implements Logger.Flush
@@ -3,7 +3,11 @@ package repy
 import (
 	"archive/zip"
 	"bytes"
+	"context"
 	"io"
+	"log/slog"
+	"path"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -15,36 +19,210 @@ const RepFileURL = "http://ug.technion.ac.il/rep/REPFILE.zip"
 // RepFileURL.
 const REPYFileName = "REPY"
 
-// ExtractFromZip reads the ZIP file from r and returns the bytes of the
-// REPY file extracted from it.
-func ExtractFromZip(r io.Reader) ([]byte, error) {
-	var repyBuffer, zipBuffer bytes.Buffer
+// ErrArchiveTooLarge is returned (wrapped) when an archive, or the data
+// extracted from it, exceeds its ArchiveLimits.
+var ErrArchiveTooLarge = errors.New("archive too large")
+
+// ArchiveLimits protect against zip bombs and other oversized archives. Zero
+// fields are replaced by those of DefaultArchiveLimits.
+type ArchiveLimits struct {
+	// MaxArchiveSize is the maximum size of the (compressed) archive read by
+	// ReadArchive.
+	MaxArchiveSize int64
+
+	// MaxMemberSize is the maximum decompressed size of a single member.
+	MaxMemberSize int64
+
+	// MaxTotalSize is the maximum amount of decompressed bytes extracted from
+	// the archive over its lifetime.
+	MaxTotalSize int64
+}
+
+// DefaultArchiveLimits are generous compared to an actual REPFILE.zip, which
+// is well under 1MB compressed and several MB decompressed.
+var DefaultArchiveLimits = ArchiveLimits{
+	MaxArchiveSize: 32 << 20,
+	MaxMemberSize:  32 << 20,
+	MaxTotalSize:   128 << 20,
+}
+
+func (l ArchiveLimits) withDefaults() ArchiveLimits {
+	if l.MaxArchiveSize == 0 {
+		l.MaxArchiveSize = DefaultArchiveLimits.MaxArchiveSize
+	}
+	if l.MaxMemberSize == 0 {
+		l.MaxMemberSize = DefaultArchiveLimits.MaxMemberSize
+	}
+	if l.MaxTotalSize == 0 {
+		l.MaxTotalSize = DefaultArchiveLimits.MaxTotalSize
+	}
+	return l
+}
+
+// Archive is a zip archive of REPY-related files, such as REPFILE.zip. It is
+// not safe for concurrent use.
+type Archive struct {
+	zr        *zip.Reader
+	limits    ArchiveLimits
+	extracted int64
+}
+
+// ArchiveMember describes a single file within an Archive.
+type ArchiveMember struct {
+	Name           string    `json:"name"`
+	Size           uint64    `json:"size"`
+	CompressedSize uint64    `json:"compressedSize"`
+	Modified       time.Time `json:"modified"`
+}
 
-	size, err := io.Copy(&zipBuffer, r)
+// NewArchive opens the zip archive of the given size in r.
+func NewArchive(r io.ReaderAt, size int64, limits ArchiveLimits) (*Archive, error) {
+	zr, err := zip.NewReader(r, size)
 	if err != nil {
 		return nil, errors.Wrapf(err, "error parsing zip archive")
 	}
+	return &Archive{zr: zr, limits: limits.withDefaults()}, nil
+}
 
-	zipReader, err := zip.NewReader(bytes.NewReader(zipBuffer.Bytes()), size)
+// ReadArchive reads an entire zip archive from r into memory, and opens it.
+func ReadArchive(r io.Reader, limits ArchiveLimits) (*Archive, error) {
+	limits = limits.withDefaults()
+
+	var zipBuffer bytes.Buffer
+	size, err := io.Copy(&zipBuffer, io.LimitReader(r, limits.MaxArchiveSize+1))
 	if err != nil {
-		return nil, errors.Wrapf(err, "error parsing zip archive")
+		return nil, errors.Wrapf(err, "error reading zip archive")
+	}
+	if size > limits.MaxArchiveSize {
+		return nil, errors.Wrapf(ErrArchiveTooLarge, "more than %d bytes", limits.MaxArchiveSize)
 	}
 
-	for _, f := range zipReader.File {
-		if f.Name == REPYFileName {
-			of, err := f.Open()
-			if err != nil {
-				return nil, errors.Wrapf(err, "error extracting file %q from zip archive", f.Name)
-			}
-			defer of.Close()
+	return NewArchive(bytes.NewReader(zipBuffer.Bytes()), size, limits)
+}
+
+// Members lists the files in the archive.
+func (a *Archive) Members() []ArchiveMember {
+	var result []ArchiveMember
+	for _, f := range a.zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		result = append(result, ArchiveMember{
+			Name:           f.Name,
+			Size:           f.UncompressedSize64,
+			CompressedSize: f.CompressedSize64,
+			Modified:       f.Modified,
+		})
+	}
+	return result
+}
+
+// Match returns the names of members matching pattern, using the syntax of
+// path.Match.
+func (a *Archive) Match(pattern string) ([]string, error) {
+	var result []string
+	for _, m := range a.Members() {
+		ok, err := path.Match(pattern, m.Name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid pattern %q", pattern)
+		}
+		if ok {
+			result = append(result, m.Name)
+		}
+	}
+	return result, nil
+}
 
-			_, err = io.Copy(&repyBuffer, of)
-			if err != nil {
-				return nil, errors.Wrapf(err, "failed to extract %q", f.Name)
+// Extract returns the decompressed contents of the named member.
+func (a *Archive) Extract(name string) ([]byte, error) {
+	for _, f := range a.zr.File {
+		if f.Name == name {
+			return a.extractFile(f)
+		}
+	}
+	return nil, errors.Errorf("didn't find a file called %q in zip archive", name)
+}
+
+func (a *Archive) extractFile(f *zip.File) ([]byte, error) {
+	limit := a.limits.MaxMemberSize
+	if remaining := a.limits.MaxTotalSize - a.extracted; remaining < limit {
+		limit = remaining
+	}
+
+	// The header's claimed size can't be trusted, but is a cheap early check.
+	if f.UncompressedSize64 > uint64(limit) {
+		return nil, errors.Wrapf(ErrArchiveTooLarge, "%q claims %d bytes, limit is %d", f.Name, f.UncompressedSize64, limit)
+	}
+
+	of, err := f.Open()
+	if err != nil {
+		return nil, errors.Wrapf(err, "error extracting file %q from zip archive", f.Name)
+	}
+	defer of.Close()
+
+	var buf bytes.Buffer
+	n, err := io.Copy(&buf, io.LimitReader(of, limit+1))
+	a.extracted += n
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to extract %q", f.Name)
+	}
+	if n > limit {
+		return nil, errors.Wrapf(ErrArchiveTooLarge, "%q exceeds %d bytes", f.Name, limit)
+	}
+	return buf.Bytes(), nil
+}
+
+// isREPYFormat returns true if data looks like a REPY file, in any of the
+// supported Charsets and Orders.
+func isREPYFormat(data []byte) bool {
+	sample := data
+	if len(sample) > detectionSampleSize {
+		sample = sample[:detectionSampleSize]
+	}
+	return bytes.Contains(sample, []byte(facultySep)) || bytes.Contains(sample, []byte(sportsFacultySep))
+}
+
+// ParseAll parses every REPY-format member whose name matches pattern (using
+// the syntax of path.Match), and returns the concatenation of their
+// faculties. Each faculty's Source is set to the name of the member it was
+// parsed from. Members which aren't in REPY format are skipped.
+func (a *Archive) ParseAll(ctx context.Context, pattern string, opts ReadOptions) (*Catalog, error) {
+	names, err := a.Match(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	result := Catalog{}
+	for _, name := range names {
+		data, err := a.Extract(name)
+		if err != nil {
+			return nil, err
+		}
+		if !isREPYFormat(data) {
+			if opts.Logger != nil {
+				opts.Logger.Log(ctx, slog.LevelInfo, "Skipping member which isn't in REPY format", slog.String("member", name))
 			}
-			return repyBuffer.Bytes(), nil
+			continue
+		}
+		c, err := ReadFileContext(ctx, bytes.NewReader(data), opts)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %q", name)
+		}
+		for _, f := range *c {
+			f.Source = name
+			result = append(result, f)
 		}
 	}
 
-	return nil, errors.Errorf("didn't find a file called %q in zip archive", REPYFileName)
+	return &result, nil
+}
+
+// ExtractFromZip reads the ZIP file from r and returns the bytes of the
+// REPY file extracted from it, using DefaultArchiveLimits.
+func ExtractFromZip(r io.Reader) ([]byte, error) {
+	a, err := ReadArchive(r, DefaultArchiveLimits)
+	if err != nil {
+		return nil, err
+	}
+	return a.Extract(REPYFileName)
 }
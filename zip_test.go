@@ -0,0 +1,140 @@
+package repy
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+type zipMember struct {
+	name string
+	data []byte
+}
+
+func makeZip(t *testing.T, members ...zipMember) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, m := range members {
+		f, err := w.Create(m.name)
+		if err != nil {
+			t.Fatalf("Failed to create zip member %q: %v", m.name, err)
+		}
+		if _, err := f.Write(m.data); err != nil {
+			t.Fatalf("Failed to write zip member %q: %v", m.name, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Failed to close zip: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func testArchiveMembers(t *testing.T) []zipMember {
+	return []zipMember{
+		{REPYFileName, readTestREPY(t, "testdata/course_statistics.repy")},
+		{"SPORT", readTestREPY(t, "testdata/course_sport_sample.repy")},
+		{"README.TXT", []byte("Not a REPY file\n")},
+	}
+}
+
+func TestArchive(t *testing.T) {
+	members := testArchiveMembers(t)
+	a, err := ReadArchive(bytes.NewReader(makeZip(t, members...)), ArchiveLimits{})
+	if err != nil {
+		t.Fatalf("ReadArchive failed: %v", err)
+	}
+
+	var gotNames []string
+	for _, m := range a.Members() {
+		gotNames = append(gotNames, m.Name)
+	}
+	if d := cmp.Diff([]string{REPYFileName, "SPORT", "README.TXT"}, gotNames); d != "" {
+		t.Errorf("Members() names diff -want +got:\n%s", d)
+	}
+
+	matched, err := a.Match("[RS]*")
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+	if d := cmp.Diff([]string{REPYFileName, "SPORT", "README.TXT"}, matched); d != "" {
+		t.Errorf("Match() diff -want +got:\n%s", d)
+	}
+
+	for _, m := range members {
+		got, err := a.Extract(m.name)
+		if err != nil {
+			t.Errorf("Extract(%q) failed: %v", m.name, err)
+		} else if !bytes.Equal(got, m.data) {
+			t.Errorf("Extract(%q) returned different contents", m.name)
+		}
+	}
+
+	if _, err := a.Extract("MISSING"); err == nil {
+		t.Errorf("Extract of missing member succeeded")
+	}
+}
+
+func TestArchiveParseAll(t *testing.T) {
+	a, err := ReadArchive(bytes.NewReader(makeZip(t, testArchiveMembers(t)...)), ArchiveLimits{})
+	if err != nil {
+		t.Fatalf("ReadArchive failed: %v", err)
+	}
+
+	c, err := a.ParseAll(context.Background(), "*", ReadOptions{})
+	if err != nil {
+		t.Fatalf("ParseAll failed: %v", err)
+	}
+
+	var got []string
+	for _, f := range *c {
+		got = append(got, f.Source+": "+f.Name)
+	}
+	want := []string{
+		REPYFileName + ": " + "פקולטה שקרית",
+		"SPORT: " + sportsFacultyName,
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("ParseAll() faculties diff -want +got:\n%s", d)
+	}
+}
+
+func TestArchiveLimits(t *testing.T) {
+	bomb := makeZip(t, zipMember{"BOMB", make([]byte, 1<<20)})
+
+	testCases := []struct {
+		name   string
+		limits ArchiveLimits
+	}{
+		{"MaxArchiveSize", ArchiveLimits{MaxArchiveSize: 100}},
+		{"MaxMemberSize", ArchiveLimits{MaxMemberSize: 1000}},
+		{"MaxTotalSize", ArchiveLimits{MaxTotalSize: 1000}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a, err := ReadArchive(bytes.NewReader(bomb), tc.limits)
+			if err == nil {
+				_, err = a.Extract("BOMB")
+			}
+			if !errors.Is(err, ErrArchiveTooLarge) {
+				t.Errorf("Got error %v; want %v", err, ErrArchiveTooLarge)
+			}
+		})
+	}
+}
+
+func TestExtractFromZip(t *testing.T) {
+	members := testArchiveMembers(t)
+	got, err := ExtractFromZip(bytes.NewReader(makeZip(t, members...)))
+	if err != nil {
+		t.Fatalf("ExtractFromZip failed: %v", err)
+	}
+	if !bytes.Equal(got, members[0].data) {
+		t.Errorf("ExtractFromZip returned different contents from %q", REPYFileName)
+	}
+}